@@ -0,0 +1,36 @@
+package bytesize
+
+import "time"
+
+// PerDay returns how much accumulates in a day at r, such as the daily log
+// volume of a service writing 5 MB/s. Any fraction of a byte is truncated,
+// and the result saturates at Max. It returns zero if r.Per is not
+// positive.
+func PerDay(r Rate) Bytes {
+	return r.over(day)
+}
+
+// PerMonth returns how much accumulates in a 30-day month at r, the month
+// used by Budget, so that 5 MB/s is 12.96 TB/month. Use PerCalendarMonth
+// for a particular month of the calendar. Any fraction of a byte is
+// truncated, and the result saturates at Max. It returns zero if r.Per is
+// not positive.
+func PerMonth(r Rate) Bytes {
+	return r.over(month)
+}
+
+// PerCalendarMonth returns how much accumulates at r over the given month
+// of the calendar, from 28 to 31 days long. Days are 24 hours, ignoring
+// daylight saving time changes. Any fraction of a byte is truncated, and
+// the result saturates at Max. It returns zero if r.Per is not positive.
+func PerCalendarMonth(r Rate, year int, m time.Month) Bytes {
+	// Day 0 of the next month is the last day of m
+	days := time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return r.over(time.Duration(days) * day)
+}
+
+// over returns how much accumulates over d at r, exactly as Budget.Prorate
+// prorates r.Amount over r.Per.
+func (r Rate) over(d time.Duration) Bytes {
+	return Budget{Amount: r.Amount, Period: r.Per}.Prorate(d)
+}
@@ -0,0 +1,58 @@
+package bytesize
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPerDayPerMonth tests accumulating rates over days and months
+func TestPerDayPerMonth(t *testing.T) {
+	tests := []struct {
+		name      string
+		rate      Rate
+		wantDay   Bytes
+		wantMonth Bytes
+	}{
+		{"5 MB/s", PerSecond(times(MB, 5)), times(GB, 432), times(GB, 12_960)},
+		{"1 GB/h", Rate{GB, time.Hour}, times(GB, 24), times(GB, 720)},
+		{"truncated", Rate{B, 7 * time.Hour}, Bytes{3, 0}, Bytes{102, 0}},
+		{"zero period", Rate{GB, 0}, None, None},
+		{"saturated", PerSecond(Bytes(Max)), Bytes(Max), Bytes(Max)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PerDay(tt.rate); got != tt.wantDay {
+				t.Errorf("PerDay(%v) = %v, want %v", tt.rate, got, tt.wantDay)
+			}
+			if got := PerMonth(tt.rate); got != tt.wantMonth {
+				t.Errorf("PerMonth(%v) = %v, want %v", tt.rate, got, tt.wantMonth)
+			}
+		})
+	}
+}
+
+// TestPerCalendarMonth tests accumulating a rate over months of different
+// lengths
+func TestPerCalendarMonth(t *testing.T) {
+	r := Rate{GB, day}
+	tests := []struct {
+		year  int
+		month time.Month
+		want  Bytes
+	}{
+		{2026, time.January, times(GB, 31)},
+		{2026, time.February, times(GB, 28)},
+		{2024, time.February, times(GB, 29)},
+		{2026, time.April, times(GB, 30)},
+		{2026, time.December, times(GB, 31)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.month.String(), func(t *testing.T) {
+			if got := PerCalendarMonth(r, tt.year, tt.month); got != tt.want {
+				t.Errorf("PerCalendarMonth(%v, %d, %v) = %v, want %v", r, tt.year, tt.month, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package bytesize
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// Sum returns the total of sizes. It returns an error if the total
+// overflows.
+func Sum(sizes ...Bytes) (Bytes, error) {
+	var total Uint128
+	for _, size := range sizes {
+		var err error
+		if total, err = total.AddBytesErr(size); err != nil {
+			return Bytes{}, err
+		}
+	}
+	return Bytes(total), nil
+}
+
+// NamedSize is a size with the name it is totaled under, such as a file
+// extension.
+type NamedSize struct {
+	Name string
+	Size Bytes
+}
+
+// TopN returns the n largest sizes in sizes, largest first, with ties in
+// name order. It returns all of them if there are no more than n.
+func TopN(sizes map[string]Bytes, n int) []NamedSize {
+	all := make([]NamedSize, 0, len(sizes))
+	for name, size := range sizes {
+		all = append(all, NamedSize{Name: name, Size: size})
+	}
+	slices.SortFunc(all, func(a, b NamedSize) int {
+		if c := Uint128(b.Size).CmpBytes(a.Size); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return all[:min(max(n, 0), len(all))]
+}
+
+// SortedByName returns the sizes in sizes, such as those DirSizeByExt
+// returns, in name order, so that output rendered from them is the same
+// on every run.
+func SortedByName(sizes map[string]Bytes) []NamedSize {
+	all := make([]NamedSize, 0, len(sizes))
+	for _, name := range slices.Sorted(maps.Keys(sizes)) {
+		all = append(all, NamedSize{Name: name, Size: sizes[name]})
+	}
+	return all
+}
@@ -0,0 +1,79 @@
+package bytesize
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestSum tests totaling sizes
+func TestSum(t *testing.T) {
+	tests := []struct {
+		sizes []Bytes
+		want  Bytes
+	}{
+		{nil, None},
+		{[]Bytes{KB}, KB},
+		{[]Bytes{KB, MB, Bytes{5, 0}}, Bytes{1_001_005, 0}},
+		{[]Bytes{Bytes(Uint128(Max).Sub64(1)), One}, Bytes(Max)},
+	}
+
+	for _, tt := range tests {
+		got, err := Sum(tt.sizes...)
+		if err != nil {
+			t.Fatalf("Sum(%v) error = %v", tt.sizes, err)
+		}
+		if got != tt.want {
+			t.Errorf("Sum(%v) = %v, want %v", tt.sizes, Uint128(got), Uint128(tt.want))
+		}
+	}
+
+	if _, err := Sum(Bytes(Max), One); err == nil {
+		t.Errorf("Sum(Max, 1) should have overflowed")
+	}
+}
+
+// TestTopN tests picking the largest sizes
+func TestTopN(t *testing.T) {
+	sizes := map[string]Bytes{
+		".log": GB,
+		".gz":  MB,
+		".txt": KB,
+		".bin": GB,
+		"":     None,
+	}
+
+	tests := []struct {
+		n    int
+		want []NamedSize
+	}{
+		{0, []NamedSize{}},
+		{-1, []NamedSize{}},
+		{1, []NamedSize{{".bin", GB}}},
+		{3, []NamedSize{{".bin", GB}, {".log", GB}, {".gz", MB}}},
+		{10, []NamedSize{{".bin", GB}, {".log", GB}, {".gz", MB}, {".txt", KB}, {"", None}}},
+	}
+
+	for _, tt := range tests {
+		if got := TopN(sizes, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("TopN(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+
+	if got := TopN(nil, 3); len(got) != 0 {
+		t.Errorf("TopN(nil, 3) = %v, want none", got)
+	}
+}
+
+// TestSortedByName tests listing sizes in name order
+func TestSortedByName(t *testing.T) {
+	sizes := map[string]Bytes{".log": GB, ".gz": MB, ".txt": KB, "": None}
+	want := []NamedSize{{"", None}, {".gz", MB}, {".log", GB}, {".txt", KB}}
+	for range 10 {
+		if got := SortedByName(sizes); !slices.Equal(got, want) {
+			t.Fatalf("SortedByName() = %v, want %v", got, want)
+		}
+	}
+	if got := SortedByName(nil); len(got) != 0 {
+		t.Errorf("SortedByName(nil) = %v, want none", got)
+	}
+}
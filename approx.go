@@ -0,0 +1,114 @@
+package bytesize
+
+import (
+	"slices"
+	"strings"
+)
+
+// ApproxPhrases is the table of phrases WithApproximate words sizes with,
+// which can be replaced with WithApproximatePhrases, such as to translate
+// them.
+type ApproxPhrases struct {
+	// About precedes a size rounded to a whole number, as in "about 2 GB".
+	About string
+	// JustUnder precedes a whole size a little above the actual size, as
+	// in "just under 1 TiB".
+	JustUnder string
+	// JustOver precedes a whole size a little below the actual size, as in
+	// "just over 2 GB".
+	JustOver string
+	// AFew replaces the number for a few of the smallest unit above bytes,
+	// as in "a few KB".
+	AFew string
+}
+
+// DefaultApproxPhrases are the English phrases WithApproximate uses.
+var DefaultApproxPhrases = ApproxPhrases{
+	About:     "about",
+	JustUnder: "just under",
+	JustOver:  "just over",
+	AFew:      "a few",
+}
+
+// WithApproximate formats sizes as rough, friendly phrases for end-user
+// messages such as quota warnings, using DefaultApproxPhrases: a size
+// within 5% of a whole number of its unit is "just under" or "just over"
+// it, as in "just over 2 GB", and a size within 5% below the next unit up
+// is "just under 1 TiB". Between 2 and 10 of the smallest unit above bytes
+// is "a few KB", and any other size is "about" the nearest whole number,
+// as in "about 47 MB". Other whole sizes, including numbers of bytes, are
+// written as they are, as in "2 GB" or "512 B"; the next unit rule comes
+// first, so 960 B is still "just under 1 KB".
+//
+// The unit system, long unit and forced unit options apply as usual. The
+// format string, significant digits, rounding and bits options have no
+// effect.
+func WithApproximate() FormatOption {
+	return WithApproximatePhrases(DefaultApproxPhrases)
+}
+
+// WithApproximatePhrases is like WithApproximate, but words sizes with
+// phrases rather than DefaultApproxPhrases.
+func WithApproximatePhrases(phrases ApproxPhrases) FormatOption {
+	return func(opts *formatOptions) error {
+		opts.approx = &phrases
+		return nil
+	}
+}
+
+// approxString formats b as a phrase under formatOptions.approx.
+func (b Bytes) approxString(formatOptions *formatOptions) string {
+	opts := *formatOptions
+	opts.bits = false
+	phrases := opts.approx
+
+	_, unitSlice := getUnitMappings(&opts)
+	unit := b.getBestUnitType(&opts, unitSlice)
+	i := slices.Index(unitSlice, unit)
+
+	// Within 5% below the next unit up is just under one of it
+	if opts.forcedUnit == nil && i >= 0 && i+1 < len(unitSlice) {
+		next := unitSlice[i+1]
+		nearly := Uint128(next).Sub(Uint128(next).Div64(20))
+		if Uint128(b).Cmp(nearly) >= 0 {
+			return approxPhrase(phrases.JustUnder, "1", approxUnitName(&opts, next, true))
+		}
+	}
+
+	n, rem := Uint128(b).QuoRemBytes(unit)
+	switch {
+	case rem.IsZero():
+		return approxPhrase("", n.String(), approxUnitName(&opts, unit, n.Cmp64(1) == 0))
+	case rem.Mul64(20).CmpBytes(unit) <= 0:
+		return approxPhrase(phrases.JustOver, n.String(), approxUnitName(&opts, unit, n.Cmp64(1) == 0))
+	case Uint128(unit).Sub(rem).Mul64(20).CmpBytes(unit) <= 0:
+		n = n.Add64(1)
+		return approxPhrase(phrases.JustUnder, n.String(), approxUnitName(&opts, unit, n.Cmp64(1) == 0))
+	}
+
+	// Round half up to the nearest whole number of the unit
+	if rem.Mul64(2).CmpBytes(unit) >= 0 {
+		n = n.Add64(1)
+	}
+	if i == 1 && n.Cmp64(2) >= 0 && n.Cmp64(10) < 0 {
+		return approxPhrase(phrases.AFew, "", approxUnitName(&opts, unit, false))
+	}
+	return approxPhrase(phrases.About, n.String(), approxUnitName(&opts, unit, n.Cmp64(1) == 0))
+}
+
+// approxUnitName returns the name formatOptions give unit, singular if one
+// is true and plural otherwise. Unless a unit is forced, formatOptions
+// choose unit for both unit and twice unit.
+func approxUnitName(formatOptions *formatOptions, unit Bytes, one bool) string {
+	sample := unit
+	if !one {
+		sample = Bytes(Uint128(unit).Mul64(2))
+	}
+	_, name := sample.formatParts(formatOptions)
+	return name
+}
+
+// approxPhrase joins the non-empty parts of a phrase with spaces.
+func approxPhrase(parts ...string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(s string) bool { return s == "" }), " ")
+}
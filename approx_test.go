@@ -0,0 +1,60 @@
+package bytesize
+
+import "testing"
+
+// TestFormatApproximate tests formatting sizes as approximate phrases
+func TestFormatApproximate(t *testing.T) {
+	french := ApproxPhrases{About: "environ", JustUnder: "un peu moins de", JustOver: "un peu plus de", AFew: "quelques"}
+	tests := []struct {
+		name string
+		b    Bytes
+		opts []FormatOption
+		want string
+	}{
+		{"zero", None, nil, "0 B"},
+		{"bytes", Bytes{512, 0}, nil, "512 B"},
+		{"whole bytes near the next unit", Bytes{960, 0}, nil, "just under 1 KB"},
+		{"one byte long", B, []FormatOption{WithLongUnits(true)}, "1 Byte"},
+		{"whole", times(GB, 2), nil, "2 GB"},
+		{"just over", times(MB, 2010), nil, "just over 2 GB"},
+		{"just under", times(MB, 1980), nil, "just under 2 GB"},
+		{"about", times(MB, 2300), nil, "about 2 GB"},
+		{"about rounded up", times(MB, 2600), nil, "about 3 GB"},
+		{"about many", Bytes{47_300_000, 0}, nil, "about 47 MB"},
+		{"just under next unit", times(GiB, 1000), []FormatOption{WithDecimalUnits(false)}, "just under 1 TiB"},
+		{"just under next unit from bytes", Bytes{990, 0}, nil, "just under 1 KB"},
+		{"a few", Bytes{3300, 0}, nil, "a few KB"},
+		{"a few binary", Bytes{5000, 0}, []FormatOption{WithDecimalUnits(false)}, "a few KiB"},
+		{"one and a bit KB", Bytes{1300, 0}, nil, "about 1 KB"},
+		{"long units", times(MB, 2300), []FormatOption{WithLongUnits(true)}, "about 2 Gigabytes"},
+		{"long units singular", times(MB, 990), []FormatOption{WithLongUnits(true)}, "just under 1 Gigabyte"},
+		{"forced unit", times(MB, 2300), []FormatOption{WithForcedUnit(MB)}, "2300 MB"},
+		{"forced unit about", Bytes{2_300_400_000, 0}, []FormatOption{WithForcedUnit(MB)}, "about 2300 MB"},
+		{"bits ignored", times(MB, 2300), []FormatOption{WithBitsOutput()}, "about 2 GB"},
+		{"max", Bytes(Max), nil, "about 340282367 QB"},
+		{"no-break space", times(MB, 2300), []FormatOption{WithNonBreakingSpace()}, "about\u00a02\u00a0GB"},
+		{"phrases", times(MB, 2300), []FormatOption{WithApproximatePhrases(french)}, "environ 2 GB"},
+		{"phrases a few", Bytes{3300, 0}, []FormatOption{WithApproximatePhrases(french)}, "quelques KB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]FormatOption{WithApproximate()}, tt.opts...)
+			got, err := tt.b.Format(opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+
+			f, err := NewFormatter(opts...)
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			if got := string(f.AppendFormat(nil, tt.b)); got != tt.want {
+				t.Errorf("AppendFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
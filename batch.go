@@ -0,0 +1,111 @@
+package bytesize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseBatch parses each of inputs like Parse, returning the results and
+// errors in the same order as inputs. errs[i] is nil if inputs[i] parsed
+// successfully. The options and the arbitrary precision scratch values are
+// set up once and shared by every input, which makes ParseBatch cheaper
+// than calling Parse in a loop when there are many inputs.
+func ParseBatch(inputs []string, opts ...ParseOption) (results []Bytes, errs []error) {
+	results = make([]Bytes, len(inputs))
+	errs = make([]error, len(inputs))
+
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return results, errs
+	}
+
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	for i, input := range inputs {
+		results[i], _, errs[i] = parse(input, parseOptions, sc, nil)
+	}
+	return results, errs
+}
+
+// ParseAll is like ParseBatch, but returns the errors as a single
+// ParseErrors listing every input that failed, or a nil error if all of
+// them parsed, so that a caller can report every bad value at once.
+func ParseAll(inputs []string, opts ...ParseOption) ([]Bytes, error) {
+	results, errs := ParseBatch(inputs, opts...)
+	var parseErrs ParseErrors
+	for i, err := range errs {
+		if err != nil {
+			parseErrs = append(parseErrs, &ParseError{Index: i, Input: inputs[i], Err: err})
+		}
+	}
+	if parseErrs != nil {
+		return results, parseErrs
+	}
+	return results, nil
+}
+
+// ParseError is the error for one of several inputs parsed together.
+type ParseError struct {
+	// Index is the position of the input among the inputs.
+	Index int
+	// Input is the input that failed to parse.
+	Input string
+	// Err is the error parsing it.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("input %d %q: %v", e.Index, e.Input, e.Err)
+}
+
+// Unwrap returns the error parsing the input.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is the error for every input that failed when several are
+// parsed together, in input order. errors.Is and errors.As search all of
+// them.
+type ParseErrors []*ParseError
+
+// Error implements the error interface, with one line per input.
+func (e ParseErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the errors for each input.
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// FormatBatch formats each of values like Format, returning the strings in
+// the same order as values. The options are resolved once into a Formatter
+// and a single buffer is reused for every value, which makes FormatBatch
+// cheaper than calling Format in a loop when rendering large listings. It
+// returns an error if any of the options are invalid.
+func FormatBatch(values []Bytes, opts ...FormatOption) ([]string, error) {
+	f, err := NewFormatter(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]string, len(values))
+	var buf []byte
+	for i, b := range values {
+		buf = f.AppendFormat(buf[:0], b)
+		out[i] = string(buf)
+	}
+	return out, nil
+}
@@ -0,0 +1,179 @@
+package bytesize
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// TestParseBatch tests that ParseBatch returns the same results and errors
+// as calling Parse on each input
+func TestParseBatch(t *testing.T) {
+	inputs := []string{"1.5 GiB", "10 MB", "", "abc", "0.1 KB", "-1 KB", "7 bytes"}
+
+	results, errs := ParseBatch(inputs)
+	if len(results) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatalf("ParseBatch() returned %d results and %d errors for %d inputs", len(results), len(errs), len(inputs))
+	}
+	for i, input := range inputs {
+		want, wantErr := Parse(input)
+		if results[i] != want {
+			t.Errorf("ParseBatch()[%d] (%q) = %v, want %v", i, input, Uint128(results[i]), Uint128(want))
+		}
+		if fmt.Sprint(errs[i]) != fmt.Sprint(wantErr) {
+			t.Errorf("ParseBatch() error[%d] (%q) = %v, want %v", i, input, errs[i], wantErr)
+		}
+	}
+}
+
+// TestParseBatchOptions tests that options apply to every input and that
+// an invalid option is reported for every input
+func TestParseBatchOptions(t *testing.T) {
+	results, errs := ParseBatch([]string{"0.1 B", "1.9 B"}, WithFractionalRounding(RoundCeil))
+	for i, want := range []Bytes{{1, 0}, {2, 0}} {
+		if errs[i] != nil || results[i] != want {
+			t.Errorf("ParseBatch()[%d] = %v, %v, want %v", i, Uint128(results[i]), errs[i], Uint128(want))
+		}
+	}
+
+	_, errs = ParseBatch([]string{"1 B", "2 B"}, WithFractionalRounding(RoundingMode(-1)))
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("ParseBatch() error[%d] = nil with an invalid option", i)
+		}
+	}
+}
+
+// TestFormatBatch tests that FormatBatch returns the same strings as calling
+// Format on each value
+func TestFormatBatch(t *testing.T) {
+	values := []Bytes{{}, B, {1536, 0}, MB, Bytes{1_500_000_000, 0}, QiB, Bytes(Max)}
+	optionSets := [][]FormatOption{
+		nil,
+		{WithDecimalUnits(false)},
+		{WithLongUnits(true), WithFormatString("%.0f %s")},
+		{WithForcedUnit(KiB)},
+	}
+
+	for _, opts := range optionSets {
+		got, err := FormatBatch(values, opts...)
+		if err != nil {
+			t.Fatalf("FormatBatch() error = %v", err)
+		}
+		for i, b := range values {
+			want, err := b.Format(opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got[i] != want {
+				t.Errorf("FormatBatch()[%d] = %q, want %q", i, got[i], want)
+			}
+		}
+	}
+
+	if _, err := FormatBatch(values, WithFormatString("")); err == nil {
+		t.Error("FormatBatch() with an invalid option returned no error")
+	}
+}
+
+var batchInputs = func() []string {
+	inputs := make([]string, 1000)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("%d.%d GiB", i, i%10)
+	}
+	return inputs
+}()
+
+// BenchmarkParseBatch benchmarks parsing many inputs with ParseBatch
+func BenchmarkParseBatch(b *testing.B) {
+	for b.Loop() {
+		ParseBatch(batchInputs)
+	}
+}
+
+// BenchmarkParseBatchLoop benchmarks parsing the same inputs as
+// BenchmarkParseBatch by calling Parse in a loop
+func BenchmarkParseBatchLoop(b *testing.B) {
+	for b.Loop() {
+		for _, input := range batchInputs {
+			Parse(input)
+		}
+	}
+}
+
+var batchValues = func() []Bytes {
+	values := make([]Bytes, 1000)
+	for i := range values {
+		values[i] = Bytes(Uint128{uint64(i), 0}.Mul64(uint64(i) * 1_000_003))
+	}
+	return values
+}()
+
+// BenchmarkFormatBatch benchmarks formatting many values with FormatBatch
+func BenchmarkFormatBatch(b *testing.B) {
+	for b.Loop() {
+		FormatBatch(batchValues)
+	}
+}
+
+// BenchmarkFormatBatchLoop benchmarks formatting the same values as
+// BenchmarkFormatBatch by calling Format in a loop
+func BenchmarkFormatBatchLoop(b *testing.B) {
+	for b.Loop() {
+		for _, v := range batchValues {
+			v.Format()
+		}
+	}
+}
+
+// TestParseAll tests that ParseAll reports every failed input at once
+func TestParseAll(t *testing.T) {
+	inputs := []string{"1 KB", "abc", "2 KB", "-1 KB"}
+	results, err := ParseAll(inputs)
+	if want := []Bytes{KB, None, times(KB, 2), None}; !slices.Equal(results, want) {
+		t.Errorf("ParseAll() = %v, want %v", results, want)
+	}
+
+	var parseErrs ParseErrors
+	if !errors.As(err, &parseErrs) {
+		t.Fatalf("ParseAll() error = %v, want ParseErrors", err)
+	}
+	if len(parseErrs) != 2 || parseErrs[0].Index != 1 || parseErrs[1].Index != 3 || parseErrs[1].Input != "-1 KB" {
+		t.Errorf("ParseAll() errors = %+v, want inputs 1 and 3", parseErrs)
+	}
+	want := "input 1 \"abc\": unknown unit: abc\ninput 3 \"-1 KB\": negative value: -1"
+	if err.Error() != want {
+		t.Errorf("ParseAll() error = %q, want %q", err.Error(), want)
+	}
+
+	// errors.As finds the error for each input
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Index != 1 {
+		t.Errorf("errors.As(*ParseError) = %v", parseErr)
+	}
+}
+
+// TestParseAllSuccess tests that ParseAll returns a nil error when every
+// input parses
+func TestParseAllSuccess(t *testing.T) {
+	results, err := ParseAll([]string{"1 KB", "2 KB"})
+	if err != nil {
+		t.Fatalf("ParseAll() error = %v", err)
+	}
+	if want := []Bytes{KB, times(KB, 2)}; !slices.Equal(results, want) {
+		t.Errorf("ParseAll() = %v, want %v", results, want)
+	}
+}
+
+// TestParseErrorsIs tests that errors.Is searches every input's error
+func TestParseErrorsIs(t *testing.T) {
+	target := errors.New("target")
+	err := error(ParseErrors{
+		{Index: 0, Input: "a", Err: errors.New("other")},
+		{Index: 1, Input: "b", Err: fmt.Errorf("wrapped: %w", target)},
+	})
+	if !errors.Is(err, target) {
+		t.Errorf("errors.Is(%v, target) = false", err)
+	}
+}
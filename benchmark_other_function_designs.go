@@ -20,6 +20,20 @@ var UnitStringToBytes = map[string]Bytes{
 	"zebibyte": ZiB, "zebibytes": ZiB, "yobibyte": YiB, "yobibytes": YiB, "ronnibyte": RiB, "ronnibytes": RiB, "quettibyte": QiB, "quettibytes": QiB,
 }
 
+// getMultiplierByUnitStringToLowerVersion is the original implementation of
+// getMultiplierByUnitString, which lowercases with strings.ToLower before
+// switching on the unit string. strings.ToLower allocates whenever the input
+// contains an uppercase letter, as in "MB" or "GiB", which is why
+// getMultiplierByUnitString now lowercases ASCII into a stack buffer instead.
+func getMultiplierByUnitStringToLowerVersion(unitStr string) (Bytes, error) {
+	unitStr = strings.ToLower(strings.TrimSpace(unitStr))
+	multiplier, ok := lookupLowerUnit(unitStr)
+	if !ok {
+		return Bytes{}, fmt.Errorf("unknown unit: %s", unitStr)
+	}
+	return multiplier, nil
+}
+
 // getMultiplierForUnit returns the multiplier Bytes value corresponding to the
 // given unit string. It looks up the unit string in the UnitStringToBytes map
 // and returns the corresponding multiplier, or an error if the unit is unknown.
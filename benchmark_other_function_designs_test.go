@@ -101,6 +101,17 @@ func TestGetMultiplierByUnitString(t *testing.T) {
 			}
 		})
 
+		t.Run("ToLower version - "+tt.name, func(t *testing.T) {
+			got, err := getMultiplierByUnitStringToLowerVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getMultiplierByUnitStringToLowerVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getMultiplierByUnitStringToLowerVersion() = %v, want %v", got, tt.want)
+			}
+		})
+
 		t.Run("Map version - "+tt.name, func(t *testing.T) {
 			got, err := getMultiplierByUnitStringMapVersion(tt.input)
 			if (err != nil) != tt.wantErr {
@@ -185,3 +196,27 @@ func BenchmarkGetMultiplierByUnitStringMapVersion_ShortBinary(b *testing.B) {
 		getMultiplierByUnitStringMapVersion("QiB")
 	}
 }
+
+func BenchmarkGetMultiplierByUnitStringToLowerVersion_LongDecimal(b *testing.B) {
+	for b.Loop() {
+		getMultiplierByUnitStringToLowerVersion("Quettabyte")
+	}
+}
+
+func BenchmarkGetMultiplierByUnitStringToLowerVersion_LongBinary(b *testing.B) {
+	for b.Loop() {
+		getMultiplierByUnitStringToLowerVersion("Quettibyte")
+	}
+}
+
+func BenchmarkGetMultiplierByUnitStringToLowerVersion_ShortDecimal(b *testing.B) {
+	for b.Loop() {
+		getMultiplierByUnitStringToLowerVersion("QB")
+	}
+}
+
+func BenchmarkGetMultiplierByUnitStringToLowerVersion_ShortBinary(b *testing.B) {
+	for b.Loop() {
+		getMultiplierByUnitStringToLowerVersion("QiB")
+	}
+}
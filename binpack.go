@@ -0,0 +1,83 @@
+package bytesize
+
+import (
+	"fmt"
+	"slices"
+)
+
+// bySizeDescending returns the indexes of items ordered from the largest
+// item to the smallest, with equal items in index order.
+func bySizeDescending(items []Bytes) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return Uint128(items[b]).CmpBytes(items[a])
+	})
+	return order
+}
+
+// PackBins assigns items to as few bins of binCapacity bytes as it can,
+// such as files to backup tapes, and returns the indexes of the items in
+// each bin, in ascending order. It uses first-fit decreasing, which places
+// each item, largest first, in the first bin with room for it and needs at
+// most 11/9 of the optimal number of bins plus one. It returns an error if
+// an item is larger than binCapacity.
+func PackBins(items []Bytes, binCapacity Bytes) ([][]int, error) {
+	var bins [][]int
+	var free []Uint128
+	for _, i := range bySizeDescending(items) {
+		if Uint128(items[i]).CmpBytes(binCapacity) > 0 {
+			return nil, fmt.Errorf("item %d of %s does not fit in a bin of %s", i, items[i], binCapacity)
+		}
+		bin := slices.IndexFunc(free, func(f Uint128) bool {
+			return f.CmpBytes(items[i]) >= 0
+		})
+		if bin == -1 {
+			bin = len(bins)
+			bins = append(bins, nil)
+			free = append(free, Uint128(binCapacity))
+		}
+		bins[bin] = append(bins[bin], i)
+		free[bin] = free[bin].SubBytes(items[i])
+	}
+	for _, bin := range bins {
+		slices.Sort(bin)
+	}
+	return bins, nil
+}
+
+// BalanceAcross assigns items to n bins so that their totals are as even
+// as it can make them, such as files to n volumes written in parallel, and
+// returns the indexes of the items in each bin, in ascending order. It
+// places each item, largest first, in the bin with the smallest total so
+// far, which gives a largest total within 4/3 of the optimal. Bins may be
+// empty if there are fewer items than bins. It returns nil if n is not
+// positive.
+func BalanceAcross(items []Bytes, n int) [][]int {
+	if n <= 0 {
+		return nil
+	}
+	bins := make([][]int, n)
+	totals := make([]Uint128, n)
+	for _, i := range bySizeDescending(items) {
+		bin := 0
+		for j := 1; j < n; j++ {
+			if totals[j].Cmp(totals[bin]) < 0 {
+				bin = j
+			}
+		}
+		bins[bin] = append(bins[bin], i)
+		// Saturate rather than panic; a full bin is never the smallest
+		total, err := totals[bin].AddBytesErr(items[i])
+		if err != nil {
+			total = Max
+		}
+		totals[bin] = total
+	}
+	for _, bin := range bins {
+		slices.Sort(bin)
+	}
+	return bins
+}
@@ -0,0 +1,81 @@
+package bytesize
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestPackBins tests packing items into bins with first-fit decreasing
+func TestPackBins(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []uint64
+		capacity uint64
+		want     [][]int
+	}{
+		{"empty", nil, 10, nil},
+		{"one bin", []uint64{3, 3, 4}, 10, [][]int{{0, 1, 2}}},
+		{"decreasing", []uint64{2, 5, 4, 7, 1, 3, 8}, 10, [][]int{{0, 6}, {3, 5}, {1, 2, 4}}},
+		{"exact fits", []uint64{5, 5, 5, 5}, 10, [][]int{{0, 1}, {2, 3}}},
+		{"zero items", []uint64{0, 10, 0}, 10, [][]int{{0, 1, 2}}},
+		{"ties in order", []uint64{6, 6, 6}, 10, [][]int{{0}, {1}, {2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]Bytes, len(tt.items))
+			for i, n := range tt.items {
+				items[i] = Bytes{n, 0}
+			}
+			got, err := PackBins(items, Bytes{tt.capacity, 0})
+			if err != nil {
+				t.Fatalf("PackBins() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PackBins() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := PackBins([]Bytes{KB, MB}, KiB); err == nil {
+		t.Errorf("PackBins() with an item larger than a bin should have errored")
+	}
+}
+
+// TestBalanceAcross tests spreading items evenly across bins
+func TestBalanceAcross(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []uint64
+		n     int
+		want  [][]int
+	}{
+		{"even", []uint64{5, 5, 5, 5}, 2, [][]int{{0, 2}, {1, 3}}},
+		{"largest first", []uint64{1, 2, 3, 4, 5, 6}, 3, [][]int{{0, 5}, {1, 4}, {2, 3}}},
+		{"one bin", []uint64{1, 2}, 1, [][]int{{0, 1}}},
+		{"more bins than items", []uint64{7}, 3, [][]int{{0}, nil, nil}},
+		{"no items", nil, 2, [][]int{nil, nil}},
+		{"no bins", []uint64{1}, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]Bytes, len(tt.items))
+			for i, n := range tt.items {
+				items[i] = Bytes{n, 0}
+			}
+			if got := BalanceAcross(items, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BalanceAcross(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBalanceAcrossOverflow tests that bin totals beyond Max do not panic
+func TestBalanceAcrossOverflow(t *testing.T) {
+	items := []Bytes{Bytes(Max), Bytes(Max), B}
+	want := [][]int{{0, 1, 2}}
+	if got := BalanceAcross(items, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("BalanceAcross(Max, Max, 1) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// The calendar periods a Budget may be written in. A month is 30 days and
+// a year 365 days, so that proration does not depend on the calendar.
+const (
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = 30 * day
+	year  = 365 * day
+)
+
+// budgetPeriods lists the period names ParseBudget accepts, with the name
+// String writes first for each period.
+var budgetPeriods = []struct {
+	period time.Duration
+	names  []string
+}{
+	{time.Second, []string{"s", "sec", "second"}},
+	{time.Minute, []string{"min", "minute"}},
+	{time.Hour, []string{"h", "hr", "hour"}},
+	{day, []string{"day", "d"}},
+	{week, []string{"week", "wk"}},
+	{month, []string{"month", "mo"}},
+	{year, []string{"year", "yr"}},
+}
+
+// Budget is an allowance of Amount bytes every Period, such as 1 TB of
+// bandwidth per month in a billing plan.
+type Budget struct {
+	Amount Bytes
+	Period time.Duration
+}
+
+// Prorate returns the part of the budget that elapsed of its period earns,
+// with any fraction of a byte truncated: half of a month of a 1 TB/month
+// budget is 500 GB. elapsed may exceed the period. It returns zero if
+// elapsed is negative or the period is not positive.
+func (b Budget) Prorate(elapsed time.Duration) Bytes {
+	if elapsed <= 0 || b.Period <= 0 {
+		return Bytes{}
+	}
+	// Amount × elapsed can exceed 128 bits even when the result does not
+	n := new(big.Int).Mul(Uint128(b.Amount).Big(), big.NewInt(int64(elapsed)))
+	n.Quo(n, big.NewInt(int64(b.Period)))
+	u, err := FromBigErr(n)
+	if err != nil {
+		return Bytes(Max)
+	}
+	return Bytes(u)
+}
+
+// String returns the budget with Amount formatted as by Bytes.String and
+// Period as a name such as "/month" or "/day", e.g. "1.00 TB/month", or as
+// "/" and a time.Duration otherwise, e.g. "1.00 GB/36h0m0s".
+func (b Budget) String() string {
+	s, _ := b.Format()
+	return s
+}
+
+// Format returns the budget like String, with Amount formatted with opts.
+func (b Budget) Format(opts ...FormatOption) (string, error) {
+	amount, err := b.Amount.Format(opts...)
+	if err != nil {
+		return "", err
+	}
+	return amount + "/" + periodName(b.Period), nil
+}
+
+// periodName returns the name String writes for period.
+func periodName(period time.Duration) string {
+	for _, p := range budgetPeriods {
+		if p.period == period {
+			return p.names[0]
+		}
+	}
+	return period.String()
+}
+
+// ParseBudget parses a budget written as a size, a slash and a period, such
+// as "1 TB/month", "500 GiB / week" or "10 GB/36h". The size is parsed like
+// Parse with opts. The period is a name such as "s", "h", "day", "week",
+// "month" or "year", case insensitive, or a duration accepted by
+// time.ParseDuration.
+func ParseBudget(s string, opts ...ParseOption) (Budget, error) {
+	amountStr, periodStr, ok := strings.Cut(s, "/")
+	if !ok {
+		return Budget{}, fmt.Errorf("invalid budget %q: missing '/'", s)
+	}
+	amount, err := Parse(amountStr, opts...)
+	if err != nil {
+		return Budget{}, fmt.Errorf("invalid budget %q: %w", s, err)
+	}
+	period, err := parsePeriod(periodStr)
+	if err != nil {
+		return Budget{}, fmt.Errorf("invalid budget %q: %w", s, err)
+	}
+	return Budget{Amount: amount, Period: period}, nil
+}
+
+// parsePeriod parses a period name or positive duration.
+func parsePeriod(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	for _, p := range budgetPeriods {
+		for _, name := range p.names {
+			if lower == name {
+				return p.period, nil
+			}
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid period %q", s)
+	}
+	return d, nil
+}
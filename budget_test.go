@@ -0,0 +1,105 @@
+package bytesize
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBudgetProrate tests prorating budgets over part of their period
+func TestBudgetProrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		budget  Budget
+		elapsed time.Duration
+		want    Bytes
+	}{
+		{"half month", Budget{TB, month}, 15 * day, Bytes{500_000_000_000, 0}},
+		{"whole month", Budget{TB, month}, month, TB},
+		{"one day", Budget{Bytes{30_000, 0}, month}, day, Bytes{1000, 0}},
+		{"truncated", Budget{Bytes{10, 0}, 3 * time.Second}, time.Second, Bytes{3, 0}},
+		{"beyond period", Budget{GB, day}, 2 * day, times(GB, 2)},
+		{"zero elapsed", Budget{GB, day}, 0, None},
+		{"negative elapsed", Budget{GB, day}, -time.Hour, None},
+		{"zero period", Budget{GB, 0}, time.Hour, None},
+		{"large amount", Budget{Bytes(Max), year}, year, Bytes(Max)},
+		{"overflow", Budget{Bytes(Max), time.Hour}, 2 * time.Hour, Bytes(Max)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.budget.Prorate(tt.elapsed); got != tt.want {
+				t.Errorf("Prorate(%v) = %v, want %v", tt.elapsed, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestBudgetString tests formatting budgets
+func TestBudgetString(t *testing.T) {
+	tests := []struct {
+		budget Budget
+		want   string
+	}{
+		{Budget{TB, month}, "1.00 TB/month"},
+		{Budget{Bytes{500_000_000, 0}, day}, "500.00 MB/day"},
+		{Budget{GB, week}, "1.00 GB/week"},
+		{Budget{GB, year}, "1.00 GB/year"},
+		{Budget{GB, time.Hour}, "1.00 GB/h"},
+		{Budget{GB, 36 * time.Hour}, "1.00 GB/36h0m0s"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.budget.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+
+	got, err := Budget{TiB, month}.Format(WithDecimalUnits(false), WithFormatString("%.0f %s"))
+	if err != nil || got != "1 TiB/month" {
+		t.Errorf("Format() = %q, %v, want %q", got, err, "1 TiB/month")
+	}
+}
+
+// TestParseBudget tests parsing budgets
+func TestParseBudget(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Budget
+		wantErr bool
+	}{
+		{"1 TB/month", Budget{TB, month}, false},
+		{"500 GiB / week", Budget{times(GiB, 500), week}, false},
+		{"10 GB/36h", Budget{times(GB, 10), 36 * time.Hour}, false},
+		{"1 MB/s", Budget{MB, time.Second}, false},
+		{"2 GB/Day", Budget{times(GB, 2), day}, false},
+		{"1 TB/mo", Budget{TB, month}, false},
+		{"1.00 TB/month", Budget{TB, month}, false},
+		{"1 TB", Budget{}, true},
+		{"1 TB/fortnight", Budget{}, true},
+		{"1 TB/-1h", Budget{}, true},
+		{"1 TB/0s", Budget{}, true},
+		{"lots/month", Budget{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseBudget(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBudget(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBudget(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBudgetRoundTrip tests that formatted budgets parse back
+func TestBudgetRoundTrip(t *testing.T) {
+	for _, b := range []Budget{{TB, month}, {GB, day}, {MB, 90 * time.Minute}} {
+		got, err := ParseBudget(b.String())
+		if err != nil || got != b {
+			t.Errorf("ParseBudget(%q) = %v, %v, want %v", b.String(), got, err, b)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package bytesize
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// defaultBufferLimit is the largest buffer MakeBuffer allocates until
+// SetBufferLimit is called.
+var defaultBufferLimit = GiB
+
+// bufferLimit holds the limit set by SetBufferLimit.
+var bufferLimit atomic.Pointer[Bytes]
+
+// SetBufferLimit sets the largest buffer MakeBuffer allocates in the
+// process, so that a size read from configuration cannot exhaust memory.
+// A limit of zero restores the default of 1 GiB. It is safe to call
+// concurrently with MakeBuffer, but it is meant to be called once at
+// startup.
+func SetBufferLimit(limit Bytes) {
+	if Uint128(limit).IsZero() {
+		bufferLimit.Store(nil)
+		return
+	}
+	bufferLimit.Store(&limit)
+}
+
+// BufferLimit returns the largest buffer MakeBuffer allocates, 1 GiB unless
+// SetBufferLimit has been called.
+func BufferLimit() Bytes {
+	if limit := bufferLimit.Load(); limit != nil {
+		return *limit
+	}
+	return defaultBufferLimit
+}
+
+// MakeBuffer returns a zeroed buffer of b bytes, such as for a buffer size
+// parsed from configuration. It returns an error matching ErrTooLarge if b
+// is more than BufferLimit, and a *RangeError if b is more than a slice can
+// hold.
+func MakeBuffer(b Bytes) ([]byte, error) {
+	if Uint128(b).Hi != 0 || Uint128(b).Lo > math.MaxInt {
+		return nil, &RangeError{Func: "MakeBuffer", Value: Uint128(b).String()}
+	}
+	if limit := BufferLimit(); Uint128(b).CmpBytes(limit) > 0 {
+		return nil, fmt.Errorf("buffer of %s exceeds %s: %w", b, limit, ErrTooLarge)
+	}
+	return make([]byte, Uint128(b).Lo), nil
+}
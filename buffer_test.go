@@ -0,0 +1,54 @@
+package bytesize
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMakeBuffer tests allocating buffers within the limit
+func TestMakeBuffer(t *testing.T) {
+	tests := []struct {
+		b       Bytes
+		wantLen int
+		wantErr error
+	}{
+		{None, 0, nil},
+		{KiB, 1024, nil},
+		{times(MiB, 4), 4 << 20, nil},
+		{Bytes(Uint128(GiB).Add64(1)), 0, ErrTooLarge},
+		{Bytes{0, 1}, 0, ErrRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.b.String(), func(t *testing.T) {
+			buf, err := MakeBuffer(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MakeBuffer() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(buf) != tt.wantLen {
+				t.Errorf("len(MakeBuffer()) = %d, want %d", len(buf), tt.wantLen)
+			}
+		})
+	}
+}
+
+// TestSetBufferLimit tests changing and restoring the buffer limit
+func TestSetBufferLimit(t *testing.T) {
+	defer SetBufferLimit(None)
+
+	SetBufferLimit(KiB)
+	if got := BufferLimit(); got != KiB {
+		t.Errorf("BufferLimit() = %s, want %s", got, KiB)
+	}
+	if _, err := MakeBuffer(KiB); err != nil {
+		t.Errorf("MakeBuffer(1 KiB) error = %v", err)
+	}
+	if _, err := MakeBuffer(Bytes{1025, 0}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("MakeBuffer(1025 B) error = %v, want ErrTooLarge", err)
+	}
+
+	SetBufferLimit(None)
+	if got := BufferLimit(); got != defaultBufferLimit {
+		t.Errorf("BufferLimit() = %s after resetting, want %s", got, defaultBufferLimit)
+	}
+}
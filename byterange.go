@@ -0,0 +1,184 @@
+package bytesize
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ByteRange is the half-open range of byte offsets [Start, End). A range
+// whose End is not after its Start is empty.
+type ByteRange struct {
+	Start, End Bytes
+}
+
+// IsEmpty reports whether r contains no offsets.
+func (r ByteRange) IsEmpty() bool {
+	return Uint128(r.End).CmpBytes(r.Start) <= 0
+}
+
+// Length returns the number of offsets in r.
+func (r ByteRange) Length() Bytes {
+	if r.IsEmpty() {
+		return None
+	}
+	return Bytes(Uint128(r.End).SubBytes(r.Start))
+}
+
+// Contains reports whether offset lies within r.
+func (r ByteRange) Contains(offset Bytes) bool {
+	return Uint128(offset).CmpBytes(r.Start) >= 0 && Uint128(offset).CmpBytes(r.End) < 0
+}
+
+// Overlaps reports whether r and o have any offset in common.
+func (r ByteRange) Overlaps(o ByteRange) bool {
+	return !r.Intersect(o).IsEmpty()
+}
+
+// Intersect returns the offsets common to r and o, which is empty if they do
+// not overlap.
+func (r ByteRange) Intersect(o ByteRange) ByteRange {
+	out := ByteRange{Start: maxBytes(r.Start, o.Start), End: minBytes(r.End, o.End)}
+	if out.IsEmpty() {
+		return ByteRange{}
+	}
+	return out
+}
+
+// Union returns the smallest range covering both r and o. The boolean is
+// false if r and o neither overlap nor touch, in which case the returned
+// range would also cover offsets in neither, and the zero range is returned
+// instead. The union with an empty range is the other range.
+func (r ByteRange) Union(o ByteRange) (ByteRange, bool) {
+	switch {
+	case r.IsEmpty():
+		return o, true
+	case o.IsEmpty():
+		return r, true
+	case Uint128(r.Start).CmpBytes(o.End) > 0 || Uint128(o.Start).CmpBytes(r.End) > 0:
+		return ByteRange{}, false
+	}
+	return ByteRange{Start: minBytes(r.Start, o.Start), End: maxBytes(r.End, o.End)}, true
+}
+
+// Subtract returns the offsets in r that are not in o, as zero, one or two
+// non-empty ranges in ascending order.
+func (r ByteRange) Subtract(o ByteRange) []ByteRange {
+	if r.IsEmpty() {
+		return nil
+	}
+	if !r.Overlaps(o) {
+		return []ByteRange{r}
+	}
+	var out []ByteRange
+	if before := (ByteRange{Start: r.Start, End: o.Start}); !before.IsEmpty() {
+		out = append(out, before)
+	}
+	if after := (ByteRange{Start: o.End, End: r.End}); !after.IsEmpty() {
+		out = append(out, after)
+	}
+	return out
+}
+
+// String returns r in interval notation with exact offsets, e.g.
+// "[0, 1024)".
+func (r ByteRange) String() string {
+	return fmt.Sprintf("[%s, %s)", Uint128(r.Start), Uint128(r.End))
+}
+
+// RangeSet is a set of byte offsets stored as sorted, non-overlapping,
+// non-adjacent ranges, as used for sparse-file or partial-download
+// bookkeeping. The zero value is an empty set. A RangeSet is not safe for
+// concurrent use.
+type RangeSet struct {
+	ranges []ByteRange
+}
+
+// Add adds the offsets in r to the set, coalescing it with any ranges it
+// overlaps or touches.
+func (s *RangeSet) Add(r ByteRange) {
+	if r.IsEmpty() {
+		return
+	}
+	out := make([]ByteRange, 0, len(s.ranges)+1)
+	for _, existing := range s.ranges {
+		if merged, ok := r.Union(existing); ok {
+			r = merged
+			continue
+		}
+		out = append(out, existing)
+	}
+	i, _ := slices.BinarySearchFunc(out, r, func(a, b ByteRange) int {
+		return Uint128(a.Start).CmpBytes(b.Start)
+	})
+	s.ranges = slices.Insert(out, i, r)
+}
+
+// Remove removes the offsets in r from the set.
+func (s *RangeSet) Remove(r ByteRange) {
+	if r.IsEmpty() {
+		return
+	}
+	out := make([]ByteRange, 0, len(s.ranges)+1)
+	for _, existing := range s.ranges {
+		out = append(out, existing.Subtract(r)...)
+	}
+	s.ranges = out
+}
+
+// Contains reports whether offset is in the set.
+func (s *RangeSet) Contains(offset Bytes) bool {
+	_, found := slices.BinarySearchFunc(s.ranges, offset, func(r ByteRange, off Bytes) int {
+		if r.Contains(off) {
+			return 0
+		}
+		return Uint128(r.Start).CmpBytes(off)
+	})
+	return found
+}
+
+// Ranges returns a copy of the ranges in the set, in ascending order.
+func (s *RangeSet) Ranges() []ByteRange {
+	return slices.Clone(s.ranges)
+}
+
+// Length returns the total number of offsets in the set.
+func (s *RangeSet) Length() Bytes {
+	var total Uint128
+	for _, r := range s.ranges {
+		total = total.AddBytes(r.Length())
+	}
+	return Bytes(total)
+}
+
+// Gaps returns the parts of within that are not in the set, in ascending
+// order; for a partial download, these are the ranges still to fetch.
+func (s *RangeSet) Gaps(within ByteRange) []ByteRange {
+	if within.IsEmpty() {
+		return nil
+	}
+	gaps := []ByteRange{within}
+	for _, r := range s.ranges {
+		var next []ByteRange
+		for _, gap := range gaps {
+			next = append(next, gap.Subtract(r)...)
+		}
+		gaps = next
+	}
+	return gaps
+}
+
+// minBytes returns the smaller of a and b.
+func minBytes(a, b Bytes) Bytes {
+	if Uint128(a).CmpBytes(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// maxBytes returns the larger of a and b.
+func maxBytes(a, b Bytes) Bytes {
+	if Uint128(a).CmpBytes(b) >= 0 {
+		return a
+	}
+	return b
+}
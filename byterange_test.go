@@ -0,0 +1,99 @@
+package bytesize
+
+import (
+	"fmt"
+	"testing"
+)
+
+// rng is a shorthand for building small ranges in tests
+func rng(start, end uint64) ByteRange {
+	return ByteRange{Start: Bytes{start, 0}, End: Bytes{end, 0}}
+}
+
+// TestByteRangeBasics tests Length, IsEmpty, Contains and String
+func TestByteRangeBasics(t *testing.T) {
+	r := rng(10, 20)
+	if got := r.Length(); got != (Bytes{10, 0}) {
+		t.Errorf("Length() = %v, want 10", Uint128(got))
+	}
+	if r.IsEmpty() {
+		t.Errorf("IsEmpty() = true, want false")
+	}
+	if !r.Contains(Bytes{10, 0}) || r.Contains(Bytes{20, 0}) || r.Contains(Bytes{9, 0}) {
+		t.Errorf("Contains() is not half-open")
+	}
+	if got := rng(20, 10).Length(); got != None {
+		t.Errorf("inverted Length() = %v, want 0", Uint128(got))
+	}
+	if got := r.String(); got != "[10, 20)" {
+		t.Errorf("String() = %q, want %q", got, "[10, 20)")
+	}
+}
+
+// TestByteRangeSetOperations tests Intersect, Union and Subtract
+func TestByteRangeSetOperations(t *testing.T) {
+	tests := []struct {
+		a, b      ByteRange
+		intersect ByteRange
+		union     ByteRange
+		unionOK   bool
+		subtract  []ByteRange
+	}{
+		{rng(0, 10), rng(5, 15), rng(5, 10), rng(0, 15), true, []ByteRange{rng(0, 5)}},
+		{rng(0, 10), rng(10, 20), ByteRange{}, rng(0, 20), true, []ByteRange{rng(0, 10)}},
+		{rng(0, 10), rng(11, 20), ByteRange{}, ByteRange{}, false, []ByteRange{rng(0, 10)}},
+		{rng(0, 20), rng(5, 10), rng(5, 10), rng(0, 20), true, []ByteRange{rng(0, 5), rng(10, 20)}},
+		{rng(5, 10), rng(0, 20), rng(5, 10), rng(0, 20), true, nil},
+		{rng(0, 10), ByteRange{}, ByteRange{}, rng(0, 10), true, []ByteRange{rng(0, 10)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s,%s", tt.a, tt.b), func(t *testing.T) {
+			if got := tt.a.Intersect(tt.b); got != tt.intersect {
+				t.Errorf("Intersect() = %s, want %s", got, tt.intersect)
+			}
+			if got, ok := tt.a.Union(tt.b); got != tt.union || ok != tt.unionOK {
+				t.Errorf("Union() = (%s, %v), want (%s, %v)", got, ok, tt.union, tt.unionOK)
+			}
+			if got := tt.a.Subtract(tt.b); fmt.Sprint(got) != fmt.Sprint(tt.subtract) {
+				t.Errorf("Subtract() = %v, want %v", got, tt.subtract)
+			}
+		})
+	}
+}
+
+// TestRangeSet tests coalescing, removal and gap computation in a RangeSet
+func TestRangeSet(t *testing.T) {
+	var s RangeSet
+	s.Add(rng(20, 30))
+	s.Add(rng(0, 10))
+	s.Add(rng(40, 50))
+	s.Add(rng(10, 15))
+	s.Add(rng(25, 45))
+
+	if got, want := fmt.Sprint(s.Ranges()), "[[0, 15) [20, 50)]"; got != want {
+		t.Fatalf("Ranges() = %s, want %s", got, want)
+	}
+	if got := s.Length(); got != (Bytes{45, 0}) {
+		t.Errorf("Length() = %v, want 45", Uint128(got))
+	}
+	if !s.Contains(Bytes{14, 0}) || s.Contains(Bytes{15, 0}) || !s.Contains(Bytes{49, 0}) || s.Contains(Bytes{50, 0}) {
+		t.Errorf("Contains() is wrong for %v", s.Ranges())
+	}
+	if got, want := fmt.Sprint(s.Gaps(rng(0, 60))), "[[15, 20) [50, 60)]"; got != want {
+		t.Errorf("Gaps() = %s, want %s", got, want)
+	}
+
+	s.Remove(rng(5, 25))
+	if got, want := fmt.Sprint(s.Ranges()), "[[0, 5) [25, 50)]"; got != want {
+		t.Errorf("Ranges() after Remove = %s, want %s", got, want)
+	}
+
+	s.Add(rng(0, 100))
+	if got, want := fmt.Sprint(s.Ranges()), "[[0, 100)]"; got != want {
+		t.Errorf("Ranges() after covering Add = %s, want %s", got, want)
+	}
+	if got := s.Gaps(rng(10, 20)); len(got) != 0 {
+		t.Errorf("Gaps() = %v, want none", got)
+	}
+}
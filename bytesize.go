@@ -5,11 +5,12 @@ package bytesize
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"slices"
 	"strings"
-	"unicode"
+	"sync"
 )
 
 // Bytes represents a byte size as a 128-bit unsigned integer, allowing for
@@ -34,34 +35,6 @@ var (
 	QB = Bytes(Uint128(RB).Mul64(1e3)) // 1e30
 )
 
-// LongDecimal maps decimal byte size units to their long names.
-var LongDecimal = map[Bytes]string{
-	KB: "Kilobyte",
-	MB: "Megabyte",
-	GB: "Gigabyte",
-	TB: "Terabyte",
-	PB: "Petabyte",
-	EB: "Exabyte",
-	ZB: "Zettabyte",
-	YB: "Yottabyte",
-	RB: "Ronnabyte",
-	QB: "Quettabyte",
-}
-
-// ShortDecimal maps decimal byte size units to their short names.
-var ShortDecimal = map[Bytes]string{
-	KB: "KB",
-	MB: "MB",
-	GB: "GB",
-	TB: "TB",
-	PB: "PB",
-	EB: "EB",
-	ZB: "ZB",
-	YB: "YB",
-	RB: "RB",
-	QB: "QB",
-}
-
 // Binary byte size units (powers of 2).
 var (
 	KiB = Bytes{1024, 0}
@@ -82,34 +55,6 @@ var (
 	QiB = Bytes{0, 1 << 36}
 )
 
-// LongBinary maps binary byte size units to their long names.
-var LongBinary = map[Bytes]string{
-	KiB: "Kibibyte",
-	MiB: "Mebibyte",
-	GiB: "Gibibyte",
-	TiB: "Tebibyte",
-	PiB: "Pebibyte",
-	EiB: "Exbibyte",
-	ZiB: "Zebibyte",
-	YiB: "Yobibyte",
-	RiB: "Ronnibyte",
-	QiB: "Quettibyte",
-}
-
-// ShortBinary maps binary byte size units to their short names.
-var ShortBinary = map[Bytes]string{
-	KiB: "KiB",
-	MiB: "MiB",
-	GiB: "GiB",
-	TiB: "TiB",
-	PiB: "PiB",
-	EiB: "EiB",
-	ZiB: "ZiB",
-	YiB: "YiB",
-	RiB: "RiB",
-	QiB: "QiB",
-}
-
 // ValidUnits lists all supported unit strings for parsing.
 var ValidUnits = []string{
 	"b",
@@ -125,169 +70,477 @@ var ValidUnits = []string{
 // IsValidUnit checks if the provided unit string is a valid unit for
 // parsing byte sizes.
 func IsValidUnit(unit string) bool {
-	unit = strings.ToLower(strings.TrimSpace(unit))
-	return slices.Contains(ValidUnits, unit)
+	_, ok := lookupUnit(unit)
+	return ok
+}
+
+// IsValidSIUnit reports whether unit is a valid unit for parsing byte
+// sizes that is a power of 1000, such as "KB" or "megabytes", or the byte
+// itself, for validators that only allow SI units.
+func IsValidSIUnit(unit string) bool {
+	system, ok := UnitSystemOf(unit)
+	return ok && system == SI
+}
+
+// IsValidIECUnit reports whether unit is a valid unit for parsing byte
+// sizes that is a power of 1024, such as "KiB" or "mebibytes", or the byte
+// itself, for validators that only allow IEC units.
+func IsValidIECUnit(unit string) bool {
+	if multiplier, ok := lookupUnit(unit); ok && multiplier == B {
+		return true
+	}
+	system, ok := UnitSystemOf(unit)
+	return ok && system == IEC
+}
+
+// UnitSystemOf returns the unit system of unit as Parse reads it: SI for
+// units such as "KB" and "megabytes" and IEC for units such as "KiB" and
+// "mebibytes". The byte belongs to every system and is reported as SI, as
+// by UnitOf. It returns false if unit is not a valid unit.
+func UnitSystemOf(unit string) (UnitSystem, bool) {
+	multiplier, ok := lookupUnit(unit)
+	if !ok {
+		return 0, false
+	}
+	u, ok := UnitOf(multiplier)
+	return u.System, ok
 }
 
 // Parse parses a string representation of a byte size (e.g., "10 MB",
 // "5.5 GiB", "100 kilobytes", "2.34 Tebibytes") returns the corresponding
-// Bytes value.
-func Parse(s string) (Bytes, error) {
-	// Trim whitespace
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return Bytes{}, fmt.Errorf("empty string")
+// Bytes value. Any fraction of a byte is truncated unless a different
+// rounding mode is chosen with WithFractionalRounding. The number may have
+// a leading '+', and a negative zero such as "-0 B" parses as zero.
+func Parse(s string, opts ...ParseOption) (Bytes, error) {
+	b, _, err := ParseExact(s, opts...)
+	return b, err
+}
+
+// ParseUint64 parses s like Parse for callers that need a uint64, such as
+// APIs that cannot take 128-bit values. It returns a *RangeError if the
+// size is 2^64 bytes or more.
+func ParseUint64(s string, opts ...ParseOption) (uint64, error) {
+	b, err := Parse(s, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if Uint128(b).Hi != 0 {
+		return 0, &RangeError{Func: "ParseUint64", Value: Uint128(b).String()}
+	}
+	return Uint128(b).Lo, nil
+}
+
+// ParseExact parses s like Parse, additionally reporting whether the result
+// is exact, i.e. whether no fraction of a byte had to be rounded away. Use
+// WithFractionalRounding to choose how such fractions are rounded.
+func ParseExact(s string, opts ...ParseOption) (b Bytes, exact bool, err error) {
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return Bytes{}, false, err
+	}
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	return parse(s, parseOptions, sc, nil)
+}
+
+// parse implements Parse. If tr is non-nil, every tokenization and
+// arithmetic step is recorded in it for ParseDebug.
+func parse(s string, opts *parseOptions, sc *ratScratch, tr *Trace) (Bytes, bool, error) {
+	tr.record("input %q", s)
+
+	// Whitespace is handled by the tokenizer, so that offsets in syntax
+	// errors refer to the untrimmed input
+	if strings.TrimSpace(s) == "" {
+		return Bytes{}, false, fmt.Errorf("empty string")
 	}
 
-	numRunes, unitRunes, err := getNumAndUnitRunes(s)
+	if opts.lenientUnits {
+		s = foldLookalikes(s)
+	}
+	numStr, unitStr, err := tokenize(s, opts.lenientUnits)
 	if err != nil {
-		return Bytes{}, fmt.Errorf("error parsing number and unit: %v", err)
+		return Bytes{}, false, fmt.Errorf("error parsing number and unit: %w", err)
+	}
+	tr.tokens(numStr, unitStr)
+
+	if opts.strictSpacing {
+		if err := checkStrictSpacing(s, numStr, unitStr); err != nil {
+			return Bytes{}, false, err
+		}
 	}
 
-	multiplier, err := getMultiplierByUnitString(string(unitRunes))
+	multiplier, err := opts.unitMultiplier(unitStr)
 	if err != nil {
-		return Bytes{}, err
+		return Bytes{}, false, err
 	}
+	tr.multiplier(multiplier)
 
 	// Parse the numeric part using big.Rat for arbitrary precision
-	numStr := string(numRunes)
+	if numStr == "" && opts.implicitOne && unitStr != "" {
+		numStr = "1"
+		tr.record("no number, so the implicit one")
+	}
 	if numStr == "" {
-		return Bytes{}, fmt.Errorf("invalid number: empty numeric part")
+		return Bytes{}, false, fmt.Errorf("invalid number: empty numeric part")
 	}
 
-	numRat := new(big.Rat)
+	// The big.Rat arithmetic from here on is most of the cost of a parse,
+	// since tokenizing is a single pass over the input
+	numRat := &sc.num
 	_, ok := numRat.SetString(numStr)
 	if !ok {
-		return Bytes{}, fmt.Errorf("invalid number: %s", numStr)
+		return Bytes{}, false, fmt.Errorf("invalid number: %s", numStr)
 	}
 
+	// Negative zero, as in "-0 B", is zero rather than negative
 	if numRat.Sign() < 0 {
-		return Bytes{}, fmt.Errorf("negative value: %s", numStr)
-	}
-
-	// Convert multiplier to big.Int
-	multiplierInt := big.NewInt(0).SetUint64(Uint128(multiplier).Lo)
-	if Uint128(multiplier).Hi > 0 {
-		// Reconstruct full 128-bit number: (Hi << 64) | Lo
-		multiplierInt.SetUint64(Uint128(multiplier).Hi)
-		multiplierInt.Lsh(multiplierInt, 64)
-		multiplierInt.Or(multiplierInt, big.NewInt(0).SetUint64(Uint128(multiplier).Lo))
+		return Bytes{}, false, fmt.Errorf("negative value: %s", numStr)
 	}
+	tr.record("number %q is exactly %s", numStr, numRat.RatString())
 
 	// Multiply the number by the multiplier: result = numRat * multiplier
-	resultRat := new(big.Rat).Mul(numRat, new(big.Rat).SetInt(multiplierInt))
+	resultRat := sc.product.Mul(numRat, bigMultiplierOf(multiplier, sc).r)
 
 	// Get the integer and fractional parts by dividing numerator by denominator
-	resultInt := new(big.Int).Div(resultRat.Num(), resultRat.Denom())
+	resultInt, remInt := sc.whole.QuoRem(resultRat.Num(), resultRat.Denom(), &sc.rem)
+	tr.product(numStr, multiplier, resultRat, resultInt)
+
+	// Round away any fraction of a byte as requested
+	exact := remInt.Sign() == 0
+	if !exact && opts.rounding.roundsUp(resultInt, remInt, resultRat.Denom(), &sc.tmp) {
+		resultInt.Add(resultInt, bigOne)
+		tr.record("rounding mode %s rounds up to %s", opts.rounding, resultInt)
+	}
 
 	// Check if result overflows 128 bits
 	if resultInt.BitLen() > 128 {
-		return Bytes{}, fmt.Errorf("value overflows Uint128: result is %d bits", resultInt.BitLen())
+		return Bytes{}, false, fmt.Errorf("value overflows Uint128: result is %d bits", resultInt.BitLen())
 	}
 
 	if resultInt.Sign() < 0 {
 		// This should never happen since we check for negative input, but
 		// just in case, handle it gracefully
-		return Bytes{}, fmt.Errorf("fatal: negative result from positive inputs")
+		return Bytes{}, false, fmt.Errorf("fatal: negative result from positive inputs")
 	}
 
 	// Convert big.Int to Uint128 (Lo and Hi)
 	// Extract Lo (lower 64 bits)
-	loInt := new(big.Int).And(resultInt, big.NewInt(-1).SetUint64(^uint64(0)))
-	lo := loInt.Uint64()
+	lo := sc.tmp.And(resultInt, bigMaxUint64).Uint64()
 
 	// Extract Hi (upper 64 bits)
-	hiInt := new(big.Int).Rsh(resultInt, 64)
-	hi := hiInt.Uint64()
+	hi := sc.tmp.Rsh(resultInt, 64).Uint64()
 
 	result := Uint128{lo, hi}
-	return Bytes(result), nil
+	tr.record("result is %s bytes", result)
+	return Bytes(result), exact, nil
 }
 
-// getNumAndUnitRunes separates the numeric part and the unit part of the
-// input string.
-func getNumAndUnitRunes(s string) ([]rune, []rune, error) {
-	foundDecimalPoint := false
-	var numRunes, unitRunes []rune
+var (
+	bigOne       = big.NewInt(1)
+	bigMaxUint64 = new(big.Int).SetUint64(^uint64(0))
+)
+
+// bigMultiplier holds the arbitrary precision forms of a unit multiplier.
+type bigMultiplier struct {
+	i *big.Int
+	r *big.Rat
+}
 
-	for _, r := range s {
-		// 1. Skip spaces between number and unit
-		if unicode.IsSpace(r) {
-			continue
+// bigMultipliers caches the bigMultiplier of every unit in the decimal and
+// binary scales, so that Parse does not rebuild them on every call. The
+// values are shared and must not be modified.
+var bigMultipliers = func() map[Bytes]bigMultiplier {
+	m := make(map[Bytes]bigMultiplier, len(decimalUnitScale)+len(binaryUnitScale))
+	for _, scale := range [][]Bytes{decimalUnitScale[:], binaryUnitScale[:]} {
+		for _, unit := range scale {
+			i := Uint128(unit).Big()
+			m[unit] = bigMultiplier{i: i, r: new(big.Rat).SetInt(i)}
 		}
-		// 2. If we hit a number or decimal point, it's part of the number
-		if r == '-' || (r >= '0' && r <= '9') || r == '.' {
-			if r == '.' {
-				if foundDecimalPoint {
-					return nil, nil, fmt.Errorf("invalid number: multiple decimal points in %s", s)
-				}
-				foundDecimalPoint = true
-			}
-			numRunes = append(numRunes, r)
-		} else {
-			// 3. The rest is the unit
-			unitRunes = append(unitRunes, r)
+	}
+	return m
+}()
+
+// bigMultiplierOf returns the bigMultiplier of m, from the cache if m is a
+// known unit and otherwise built in sc.
+func bigMultiplierOf(m Bytes, sc *ratScratch) bigMultiplier {
+	if bm, ok := bigMultipliers[m]; ok {
+		return bm
+	}
+	// Reconstruct full 128-bit number: (Hi << 64) | Lo
+	i := sc.mult.SetUint64(Uint128(m).Hi)
+	i.Lsh(i, 64)
+	i.Or(i, sc.tmp.SetUint64(Uint128(m).Lo))
+	return bigMultiplier{i: i, r: sc.multRat.SetInt(i)}
+}
+
+// ratScratch holds the arbitrary precision values used to compute the
+// result of a parse. Callers that parse repeatedly, such as Decoder, keep
+// one around so that the backing storage of these values is reused from
+// one parse to the next instead of being allocated for every intermediate
+// result.
+type ratScratch struct {
+	num     big.Rat
+	product big.Rat
+	multRat big.Rat
+	mult    big.Int
+	whole   big.Int
+	rem     big.Int
+	tmp     big.Int
+}
+
+// ratScratchPool holds the ratScratch values of one-shot parses, so that
+// they do not allocate a new one, which escapes to the heap, every time.
+var ratScratchPool = sync.Pool{
+	New: func() any { return new(ratScratch) },
+}
+
+// maxPooledScratchBits is the size above which a ratScratch is dropped
+// rather than pooled, so that one huge input does not pin its storage.
+const maxPooledScratchBits = 1 << 12
+
+// getRatScratch returns a ratScratch from the pool.
+func getRatScratch() *ratScratch {
+	return ratScratchPool.Get().(*ratScratch)
+}
+
+// putRatScratch returns sc to the pool, unless it has grown too large.
+func putRatScratch(sc *ratScratch) {
+	if sc.product.Num().BitLen() > maxPooledScratchBits || sc.product.Denom().BitLen() > maxPooledScratchBits {
+		return
+	}
+	ratScratchPool.Put(sc)
+}
+
+type parseOptions struct {
+	// How to round a result that is not a whole number of bytes
+	rounding RoundingMode
+
+	// Accept Kubernetes quantity suffixes such as "Mi" and "k"
+	kubernetes bool
+
+	// Require exactly one space between the number and the unit and no
+	// other whitespace
+	strictSpacing bool
+
+	// Accept alternate spellings of unit names
+	lenientUnits bool
+
+	// Read a unit with no number as one of the unit
+	implicitOne bool
+
+	// Accept octet units such as "Mo"
+	octets bool
+}
+
+func newParseOptions(opts ...ParseOption) (*parseOptions, error) {
+	parseOptions := &parseOptions{
+		rounding: RoundTruncate,
+	}
+	for _, opt := range opts {
+		if err := opt(parseOptions); err != nil {
+			return nil, err
 		}
 	}
+	return parseOptions, nil
+}
 
-	return numRunes, unitRunes, nil
+// ParseOption defines a functional option for configuring the parsing of
+// byte sizes.
+type ParseOption func(*parseOptions) error
+
+// WithFractionalRounding allows you to specify how a parsed value that is
+// not a whole number of bytes, such as "0.00001 KB", is rounded. The
+// default is RoundTruncate; billing code will usually want RoundCeil.
+func WithFractionalRounding(mode RoundingMode) ParseOption {
+	return func(opts *parseOptions) error {
+		if !mode.valid() {
+			return fmt.Errorf("invalid rounding mode: %d", mode)
+		}
+		opts.rounding = mode
+		return nil
+	}
+}
+
+// WithImplicitOne makes Parse read a unit with no number as one of the
+// unit, so that "GiB" is 1 GiB, as several CLIs do. Input with neither is
+// still an error.
+func WithImplicitOne() ParseOption {
+	return func(opts *parseOptions) error {
+		opts.implicitOne = true
+		return nil
+	}
+}
+
+// WithStrictSpacing makes Parse require exactly "<number> <unit>", with a
+// single ASCII space between the number and the unit and no other
+// whitespace, so that validators of machine-generated input can reject
+// anything else deterministically. A violation is reported as a
+// *SyntaxError.
+func WithStrictSpacing() ParseOption {
+	return func(opts *parseOptions) error {
+		opts.strictSpacing = true
+		return nil
+	}
+}
+
+// checkStrictSpacing returns a *SyntaxError at the first byte where s
+// differs from num and unit separated by a single space.
+func checkStrictSpacing(s, num, unit string) error {
+	want := num + " " + unit
+	if s == want {
+		return nil
+	}
+	i := 0
+	for i < len(s) && i < len(want) && s[i] == want[i] {
+		i++
+	}
+	return &SyntaxError{s, i, "invalid spacing: want a single space between number and unit"}
+}
+
+// SyntaxError records a malformed byte size string and the byte offset at
+// which the problem was found.
+type SyntaxError struct {
+	Input  string // the string being parsed
+	Offset int    // byte offset of the offending character in Input
+	Msg    string // description of the problem
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at offset %d in %q", e.Msg, e.Offset, e.Input)
+}
+
+// tokenState is the state of the tokenizer.
+type tokenState int
+
+const (
+	stateLeading  tokenState = iota // leading whitespace
+	stateSign                       // after the sign
+	stateNumber                     // inside the number
+	stateGap                        // whitespace between number and unit
+	stateUnit                       // inside the unit
+	stateTrailing                   // trailing whitespace
+)
+
+// maxUnitLen is the length of the longest unit name, "quettabytes".
+const maxUnitLen = len("quettabytes")
+
+// unitMultiplier returns the multiplier for unitStr, accepting the extra
+// units enabled by the parse options.
+func (opts *parseOptions) unitMultiplier(unitStr string) (Bytes, error) {
+	if opts.kubernetes {
+		if multiplier, ok := kubernetesSuffixes[unitStr]; ok {
+			return multiplier, nil
+		}
+	}
+	if opts.lenientUnits {
+		if multiplier, ok := lookupLenientUnit(unitStr); ok {
+			return multiplier, nil
+		}
+	}
+	if opts.octets {
+		if multiplier, ok := lookupOctetUnit(unitStr); ok {
+			return multiplier, nil
+		}
+	}
+	return getMultiplierByUnitString(unitStr)
 }
 
 // getMultiplierByUnitString returns the multiplier Bytes value corresponding
 // to the given unit string.
 func getMultiplierByUnitString(unitStr string) (Bytes, error) {
-	unitStr = strings.ToLower(strings.TrimSpace(unitStr))
-	switch unitStr {
+	if multiplier, ok := lookupUnit(unitStr); ok {
+		return multiplier, nil
+	}
+	return Bytes{}, fmt.Errorf("unknown unit: %s", strings.ToLower(strings.TrimSpace(unitStr)))
+}
+
+// lookupUnit returns the multiplier for a unit string, ignoring case and
+// surrounding whitespace. ASCII input is lowercased into a stack buffer,
+// avoiding the allocation strings.ToLower makes for units such as "MB".
+func lookupUnit(unitStr string) (Bytes, bool) {
+	unitStr = strings.TrimSpace(unitStr)
+	var buf [maxUnitLen]byte
+	lower, ok := appendLowerASCII(buf[:0], unitStr)
+	if !ok {
+		lower = []byte(strings.ToLower(unitStr))
+	}
+	return lookupLowerUnit(string(lower))
+}
+
+// appendLowerASCII appends the ASCII lowercase form of s to dst. It returns
+// false, leaving the result unspecified, if s contains non-ASCII bytes or
+// does not fit in the capacity of dst.
+func appendLowerASCII(dst []byte, s string) ([]byte, bool) {
+	if len(s) > cap(dst)-len(dst) {
+		return dst, false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x80 {
+			return dst, false
+		}
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+	return dst, true
+}
+
+// lookupLowerUnit returns the multiplier for an already lowercased unit
+// string.
+func lookupLowerUnit(unit string) (Bytes, bool) {
+	switch unit {
 	// Base unit
 	case "b", "byte", "bytes":
-		return B, nil
+		return B, true
 
 	// Decimal units
 	case "kb", "kilobyte", "kilobytes":
-		return KB, nil
+		return KB, true
 	case "mb", "megabyte", "megabytes":
-		return MB, nil
+		return MB, true
 	case "gb", "gigabyte", "gigabytes":
-		return GB, nil
+		return GB, true
 	case "tb", "terabyte", "terabytes":
-		return TB, nil
+		return TB, true
 	case "pb", "petabyte", "petabytes":
-		return PB, nil
+		return PB, true
 	case "eb", "exabyte", "exabytes":
-		return EB, nil
+		return EB, true
 	case "zb", "zettabyte", "zettabytes":
-		return ZB, nil
+		return ZB, true
 	case "yb", "yottabyte", "yottabytes":
-		return YB, nil
+		return YB, true
 	case "rb", "ronnabyte", "ronnabytes":
-		return RB, nil
+		return RB, true
 	case "qb", "quettabyte", "quettabytes":
-		return QB, nil
+		return QB, true
 
 	// Binary units
 	case "kib", "kibibyte", "kibibytes":
-		return KiB, nil
+		return KiB, true
 	case "mib", "mebibyte", "mebibytes":
-		return MiB, nil
+		return MiB, true
 	case "gib", "gibibyte", "gibibytes":
-		return GiB, nil
+		return GiB, true
 	case "tib", "tebibyte", "tebibytes":
-		return TiB, nil
+		return TiB, true
 	case "pib", "pebibyte", "pebibytes":
-		return PiB, nil
+		return PiB, true
 	case "eib", "exbibyte", "exbibytes":
-		return EiB, nil
+		return EiB, true
 	case "zib", "zebibyte", "zebibytes":
-		return ZiB, nil
+		return ZiB, true
 	case "yib", "yobibyte", "yobibytes":
-		return YiB, nil
+		return YiB, true
 	case "rib", "ronnibyte", "ronnibytes":
-		return RiB, nil
+		return RiB, true
 	case "qib", "quettibyte", "quettibytes":
-		return QiB, nil
+		return QiB, true
 
 	default:
-		return Bytes{}, fmt.Errorf("unknown unit: %s", unitStr)
+		return Bytes{}, false
 	}
 }
 
@@ -321,13 +574,52 @@ type formatOptions struct {
 	formatStr string
 
 	// Forced unit for formatting, nil if automatic
-	forcedUnitType *Bytes
+	forcedUnit *Unit
 
 	// Use long unit names if true, short unit names if false
 	longUnits bool
 
 	// Use decimal (SI) units if true, binary (IEC) units if false
 	decimalUnits bool
+
+	// Use Kubernetes quantity suffixes, ignoring the options above
+	kubernetes bool
+
+	// Unit for formatting zero, nil to format it like any other value
+	zeroUnit *Unit
+
+	// String to format zero as, nil to format it like any other value
+	zeroString *string
+
+	// Suffixes appended to long unit names for one and for other values
+	singularSuffix, pluralSuffix string
+
+	// Write "byte" or "bytes" rather than "B" for short unit names
+	wordBytes bool
+
+	// Short unit names that replace the built-in ones, by unit
+	unitSymbols map[Bytes]string
+
+	// Significant digits for the value, 0 for the precision of formatStr
+	sigDigits int
+
+	// Rounding of the value to the precision of formatStr or sigDigits
+	rounding RoundingMode
+
+	// Format the number of bits rather than bytes
+	bits bool
+
+	// Replacement for the spaces in the output, "" to keep them
+	space string
+
+	// Phrases for formatting approximately, nil to format exactly
+	approx *ApproxPhrases
+
+	// Returns the text to wrap the output in for its value and unit
+	colorizer func(value float64, unit string) (before, after string)
+
+	// Write octet unit names such as "Mo" rather than byte unit names
+	octets bool
 }
 
 // These default options can be overridden by users of this package
@@ -349,11 +641,27 @@ var (
 
 func newFormatOptions() *formatOptions {
 	return &formatOptions{
-		formatStr:      DefaultFormatStr,
-		forcedUnitType: DefaultForcedUnitType,
-		longUnits:      DefaultLongUnits,
-		decimalUnits:   DefaultDecimalUnits,
+		formatStr:    DefaultFormatStr,
+		forcedUnit:   defaultForcedUnit(),
+		longUnits:    DefaultLongUnits,
+		decimalUnits: DefaultDecimalUnits,
+		pluralSuffix: "s",
+		rounding:     RoundHalfEven,
+	}
+}
+
+// defaultForcedUnit returns the Unit for DefaultForcedUnitType, or nil if
+// it is not set. A value that is not a unit is formatted as if it were a
+// unit named "B".
+func defaultForcedUnit() *Unit {
+	if DefaultForcedUnitType == nil {
+		return nil
 	}
+	u, ok := UnitOf(*DefaultForcedUnitType)
+	if !ok {
+		u = Unit{Factor: *DefaultForcedUnitType, Short: "B", Long: "Byte", Symbol: "B"}
+	}
+	return &u
 }
 
 // FormatOption defines a functional option for configuring the formatting
@@ -378,18 +686,163 @@ func WithFormatString(formatStr string) FormatOption {
 // appropriate unit based on the value.
 func WithForcedUnit(unit Bytes) FormatOption {
 	return func(opts *formatOptions) error {
-		switch unit {
-		case B, KB, MB, GB, TB, PB, EB, ZB, YB, RB, QB:
-			opts.decimalUnits = true
-			opts.forcedUnitType = &unit
-			return nil
-		case KiB, MiB, GiB, TiB, PiB, EiB, ZiB, YiB, RiB, QiB:
-			opts.decimalUnits = false
-			opts.forcedUnitType = &unit
-			return nil
-		default:
+		u, ok := UnitOf(unit)
+		if !ok {
 			return fmt.Errorf("invalid forced unit: %v", unit)
 		}
+		opts.decimalUnits = u.System == SI
+		opts.forcedUnit = &u
+		return nil
+	}
+}
+
+// WithUnit is like WithForcedUnit, but takes a Unit, so that units that
+// share a factor, such as the IEC KiB and the JEDEC KB, can be told apart.
+func WithUnit(unit Unit) FormatOption {
+	return func(opts *formatOptions) error {
+		if Uint128(unit.Factor).IsZero() {
+			return fmt.Errorf("invalid unit: zero factor")
+		}
+		if unit.Short == "" || unit.Long == "" {
+			return fmt.Errorf("invalid unit: missing name")
+		}
+		opts.forcedUnit = &unit
+		return nil
+	}
+}
+
+// WithZeroUnit formats zero in unit rather than in bytes, so that a column
+// of sizes can show "0.00 GB" alongside "1.50 GB". Other values are
+// formatted as usual.
+func WithZeroUnit(unit Bytes) FormatOption {
+	return func(opts *formatOptions) error {
+		u, ok := UnitOf(unit)
+		if !ok {
+			return fmt.Errorf("invalid zero unit: %v", unit)
+		}
+		opts.zeroUnit = &u
+		return nil
+	}
+}
+
+// WithZeroString formats zero as s, such as "—" or "" for an empty cell in
+// a dashboard. It takes precedence over WithZeroUnit and
+// WithKubernetesSuffixes.
+func WithZeroString(s string) FormatOption {
+	return func(opts *formatOptions) error {
+		opts.zeroString = &s
+		return nil
+	}
+}
+
+// WithPluralSuffix replaces the suffixes appended to long unit names, which
+// are "" for a value of exactly one and "s" otherwise, e.g.
+// WithPluralSuffix("(s)", "(s)") for "1.00 Kilobyte(s)". It has no effect
+// on short unit names.
+func WithPluralSuffix(singular, plural string) FormatOption {
+	return func(opts *formatOptions) error {
+		opts.singularSuffix = singular
+		opts.pluralSuffix = plural
+		return nil
+	}
+}
+
+// WithWordBytesBelowKB writes values formatted in bytes with the word
+// "byte" or "bytes" rather than "B" when using short unit names, as some
+// style guides require, e.g. "1.00 byte" and "512.00 bytes" but "1.50 KB".
+// Combine it with WithFormatString("%.0f %s") for "1 byte". The plural suffix
+// is the one set by WithPluralSuffix.
+func WithWordBytesBelowKB() FormatOption {
+	return func(opts *formatOptions) error {
+		opts.wordBytes = true
+		return nil
+	}
+}
+
+// WithUnitSymbols replaces the short names of individual units with the
+// given symbols, such as "Go" for GB when writing French octets or "K" for
+// KB, without defining a custom unit system. Units missing from symbols keep
+// their usual names, and long unit names are unaffected. The map is copied.
+func WithUnitSymbols(symbols map[Bytes]string) FormatOption {
+	return func(opts *formatOptions) error {
+		opts.unitSymbols = maps.Clone(symbols)
+		return nil
+	}
+}
+
+// WithSignificantDigits formats the value with n significant digits rather
+// than a fixed number of decimal places, as go-humanize does: with n = 3,
+// "999 B", "1.02 KB" and "12.3 GB". Digits of the whole part are kept, so
+// 12345 bytes forced to B is "12345 B". It overrides the precision of an
+// 'f' verb in the format string and has no effect on other verbs.
+func WithSignificantDigits(n int) FormatOption {
+	return func(opts *formatOptions) error {
+		if n < 1 {
+			return fmt.Errorf("invalid significant digits: %d", n)
+		}
+		opts.sigDigits = n
+		return nil
+	}
+}
+
+// WithFormatRounding rounds the value to the precision of the format string,
+// or to the digits of WithSignificantDigits, under mode rather than
+// half-to-even, e.g. RoundCeil so that a quota is never understated:
+// 1.001 GB is "1.01 GB". It has no effect on verbs other than 'f'.
+func WithFormatRounding(mode RoundingMode) FormatOption {
+	return func(opts *formatOptions) error {
+		if !mode.valid() {
+			return fmt.Errorf("invalid rounding mode: %d", mode)
+		}
+		opts.rounding = mode
+		return nil
+	}
+}
+
+// WithBitsOutput formats the number of bits in the value rather than the
+// number of bytes, for bandwidth displays: 100 MB is "800.00 Mb", and
+// "800.00 Megabits" with long unit names. The unit is chosen for the
+// number of bits, and the unit system and forced unit options apply to
+// the bit units as they would to the byte units, so 1 MiB is "8.00 Mib"
+// with WithDecimalUnits(false). It has no effect on Kubernetes output or
+// on WithUnitSymbols.
+func WithBitsOutput() FormatOption {
+	return func(opts *formatOptions) error {
+		opts.bits = true
+		return nil
+	}
+}
+
+// WithColorizer wraps the output in the text colorizer returns for the
+// value in the chosen unit and the unit name, so that a CLI can color
+// sizes by threshold with ANSI escape codes without parsing the formatted
+// string, e.g. returning "\x1b[31m" and "\x1b[0m" to show sizes of 100 GB
+// and up in red. It has no effect on Kubernetes output, WithZeroString or
+// WithApproximate.
+func WithColorizer(colorizer func(value float64, unit string) (before, after string)) FormatOption {
+	return func(opts *formatOptions) error {
+		opts.colorizer = colorizer
+		return nil
+	}
+}
+
+// WithNonBreakingSpace replaces the spaces in the output, such as the one
+// between the value and the unit, with no-break spaces (U+00A0), so that
+// HTML and other wrapped text never splits a size across lines.
+func WithNonBreakingSpace() FormatOption {
+	return func(opts *formatOptions) error {
+		opts.space = "\u00a0"
+		return nil
+	}
+}
+
+// WithNarrowNonBreakingSpace is like WithNonBreakingSpace, but uses the
+// narrow no-break space (U+202F) that SI typography prefers between a
+// value and its unit.
+func WithNarrowNonBreakingSpace() FormatOption {
+	return func(opts *formatOptions) error {
+		opts.space = "\u202f"
+		return nil
 	}
 }
 
@@ -431,42 +884,122 @@ func (b Bytes) Format(opts ...FormatOption) (string, error) {
 }
 
 func (b Bytes) format(opts ...FormatOption) (string, error) {
+	formatOptions, err := resolveFormatOptions(opts...)
+	if err != nil {
+		return "", err
+	}
+	return b.formatWith(formatOptions), nil
+}
+
+// formatWith formats b with already resolved options.
+func (b Bytes) formatWith(formatOptions *formatOptions) string {
+	if formatOptions.zeroString != nil && Uint128(b).IsZero() {
+		return *formatOptions.zeroString
+	}
+	if formatOptions.kubernetes {
+		return b.kubernetesString()
+	}
+	if formatOptions.approx != nil {
+		return formatOptions.replaceSpaces(b.approxString(formatOptions))
+	}
+	value, unitName := b.formatParts(formatOptions)
+	s := fmt.Sprintf(formatOptions.formatStr, formatOptions.formatValue(value), unitName)
+	if formatOptions.space != "" {
+		s = strings.ReplaceAll(s, " ", formatOptions.space)
+	}
+	if formatOptions.colorizer != nil {
+		before, after := formatOptions.colorize(value, unitName)
+		s = before + s + after
+	}
+	return s
+}
+
+// appendFormatWith is like formatWith, but appends to dst.
+func (b Bytes) appendFormatWith(dst []byte, formatOptions *formatOptions) []byte {
+	if formatOptions.zeroString != nil && Uint128(b).IsZero() {
+		return append(dst, *formatOptions.zeroString...)
+	}
+	if formatOptions.kubernetes {
+		return append(dst, b.kubernetesString()...)
+	}
+	if formatOptions.approx != nil {
+		return append(dst, formatOptions.replaceSpaces(b.approxString(formatOptions))...)
+	}
+	if formatOptions.space != "" || formatOptions.colorizer != nil {
+		return append(dst, b.formatWith(formatOptions)...)
+	}
+	value, unitName := b.formatParts(formatOptions)
+	return fmt.Appendf(dst, formatOptions.formatStr, formatOptions.formatValue(value), unitName)
+}
+
+// colorize calls the colorizer for value and unitName.
+func (formatOptions *formatOptions) colorize(value quotient, unitName string) (before, after string) {
+	f, _ := value.bigFloat().Float64()
+	return formatOptions.colorizer(f, unitName)
+}
+
+// replaceSpaces replaces the spaces in s as formatOptions.space requests.
+func (formatOptions *formatOptions) replaceSpaces(s string) string {
+	if formatOptions.space == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, " ", formatOptions.space)
+}
+
+// formatValue returns what formatStr is applied to for value.
+func (formatOptions *formatOptions) formatValue(value quotient) any {
+	if formatOptions.sigDigits > 0 || formatOptions.rounding != RoundHalfEven {
+		return roundedQuotient{value, formatOptions.sigDigits, formatOptions.rounding}
+	}
+	return value
+}
+
+// resolveFormatOptions applies opts on top of the defaults.
+func resolveFormatOptions(opts ...FormatOption) (*formatOptions, error) {
 	formatOptions := newFormatOptions()
+	applyDefaultFormatOptions(formatOptions)
 	for _, opt := range opts {
 		if err := opt(formatOptions); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
+	return formatOptions, nil
+}
+
+// formatParts returns the value and unit name that formatOptions.formatStr
+// is applied to.
+func (b Bytes) formatParts(formatOptions *formatOptions) (quotient, string) {
+	if formatOptions.zeroUnit != nil && Uint128(b).IsZero() {
+		zeroOptions := *formatOptions
+		zeroOptions.forcedUnit = formatOptions.zeroUnit
+		formatOptions = &zeroOptions
+	}
 
 	// Select the appropriate unit maps
 	unitMap, unitSlice := getUnitMappings(formatOptions)
 
 	// Determine which unit to use
-	bestUnit := b.getBestUnitType(formatOptions, unitSlice)
-
-	// Calculate the value in the chosen unit using big.Float for precision
-	bBig := big.NewInt(0).SetUint64(Uint128(b).Lo)
-	if Uint128(b).Hi > 0 {
-		bBig.SetUint64(Uint128(b).Hi)
-		bBig.Lsh(bBig, 64)
-		bBig.Add(bBig, big.NewInt(0).SetUint64(Uint128(b).Lo))
+	count := b
+	if formatOptions.bits {
+		count = bitCount(b)
 	}
+	bestUnit := count.getBestUnitType(formatOptions, unitSlice)
 
-	unitBig := big.NewInt(0).SetUint64(Uint128(bestUnit).Lo)
-	if Uint128(bestUnit).Hi > 0 {
-		unitBig.SetUint64(Uint128(bestUnit).Hi)
-		unitBig.Lsh(unitBig, 64)
-		unitBig.Add(unitBig, big.NewInt(0).SetUint64(Uint128(bestUnit).Lo))
+	// Calculate the value in the chosen unit exactly; quotient formats
+	// itself without math/big for the usual %f verbs
+	value := quotient{n: Uint128(b), d: Uint128(bestUnit)}
+	if formatOptions.bits {
+		value = bitQuotient(b, bestUnit)
 	}
 
-	// Use big.Float to calculate the value with proper precision
-	bFloat := big.NewFloat(0).SetInt(bBig)
-	unitFloat := big.NewFloat(0).SetInt(unitBig)
-	value := big.NewFloat(0).Quo(bFloat, unitFloat)
-
 	// Get the unit name
-	// fmt.Printf("UnitMap: %v\n", unitMap)
 	unitName, found := unitMap[bestUnit]
+	if u := formatOptions.forcedUnit; u != nil {
+		unitName, found = u.Short, true
+		if formatOptions.longUnits {
+			unitName = u.Long
+		}
+	}
 	if !found {
 		if formatOptions.longUnits {
 			unitName = "Byte"
@@ -474,32 +1007,97 @@ func (b Bytes) format(opts ...FormatOption) (string, error) {
 			unitName = "B"
 		}
 	}
-	if formatOptions.longUnits && value.Cmp(big.NewFloat(1)) != 0 {
-		unitName += "s"
+	suffixed := formatOptions.longUnits
+	if formatOptions.wordBytes && !formatOptions.longUnits && bestUnit == B {
+		unitName, suffixed = "byte", true
+	}
+	symbolized := false
+	if symbol, ok := formatOptions.unitSymbols[bestUnit]; ok && !formatOptions.longUnits && !formatOptions.bits {
+		unitName, suffixed, symbolized = symbol, false, true
+	}
+	if formatOptions.bits {
+		unitName = bitUnitName(unitName)
+	} else if formatOptions.octets && !symbolized {
+		unitName = octetUnitName(unitName)
+	}
+	if suffixed {
+		if value.isOne() {
+			unitName += formatOptions.singularSuffix
+		} else {
+			unitName += formatOptions.pluralSuffix
+		}
 	}
 
-	return fmt.Sprintf(formatOptions.formatStr, value, unitName), nil
+	return value, unitName
+}
+
+// bitCount returns the number of bits in b, saturating at Max.
+func bitCount(b Bytes) Bytes {
+	bits, err := Uint128(b).Mul64Err(8)
+	if err != nil {
+		return Bytes(Max)
+	}
+	return Bytes(bits)
 }
 
+// bitQuotient returns the number of bits in b as a multiple of unit bits.
+func bitQuotient(b, unit Bytes) quotient {
+	// Every unit above the byte is a multiple of 8, which keeps the
+	// numerator in range. Otherwise b of 2^125 bytes or more has more bits
+	// than a Uint128 holds, so the numerator is shifted instead.
+	if Uint128(unit).Lo%8 == 0 {
+		return quotient{n: Uint128(b), d: Uint128(unit).Rsh(3)}
+	}
+	if bits, err := Uint128(b).Mul64Err(8); err == nil {
+		return quotient{n: bits, d: Uint128(unit)}
+	}
+	return quotient{n: Uint128(b), d: Uint128(unit), shift: 3}
+}
+
+// bitUnitName returns the name of the bit unit with the prefix of the byte
+// unit name, such as "Mb" for "MB" and "Kilobit" for "Kilobyte".
+func bitUnitName(name string) string {
+	if prefix, ok := strings.CutSuffix(name, "byte"); ok {
+		return prefix + "bit"
+	}
+	if prefix, ok := strings.CutSuffix(name, "Byte"); ok {
+		return prefix + "Bit"
+	}
+	if prefix, ok := strings.CutSuffix(name, "B"); ok {
+		return prefix + "b"
+	}
+	return name
+}
+
+// decimalUnitScale and binaryUnitScale list the units used for automatic
+// unit selection in ascending order. They are built once, so that format
+// neither allocates them per call nor is affected by reassignment of the
+// exported unit variables.
+var (
+	decimalUnitScale = [...]Bytes{B, KB, MB, GB, TB, PB, EB, ZB, YB, RB, QB}
+	binaryUnitScale  = [...]Bytes{B, KiB, MiB, GiB, TiB, PiB, EiB, ZiB, YiB, RiB, QiB}
+)
+
 // getUnitMappings returns the appropriate unit map and unit slice based on the
 // provided format options. It selects between decimal and binary units, as well
 // as long and short unit names, to ensure that the formatting uses the correct
-// units and names based on the user's preferences.
+// units and names based on the user's preferences. The unit slice is in
+// ascending order.
 func getUnitMappings(formatOptions *formatOptions) (unitMap map[Bytes]string, unitSlice []Bytes) {
 	if formatOptions.decimalUnits {
 		if formatOptions.longUnits {
-			unitMap = LongDecimal
+			unitMap = longDecimal
 		} else {
-			unitMap = ShortDecimal
+			unitMap = shortDecimal
 		}
-		unitSlice = []Bytes{QB, RB, YB, ZB, EB, PB, TB, GB, MB, KB, B}
+		unitSlice = decimalUnitScale[:]
 	} else {
 		if formatOptions.longUnits {
-			unitMap = LongBinary
+			unitMap = longBinary
 		} else {
-			unitMap = ShortBinary
+			unitMap = shortBinary
 		}
-		unitSlice = []Bytes{QiB, RiB, YiB, ZiB, EiB, PiB, TiB, GiB, MiB, KiB, B}
+		unitSlice = binaryUnitScale[:]
 	}
 
 	return unitMap, unitSlice
@@ -508,25 +1106,24 @@ func getUnitMappings(formatOptions *formatOptions) (unitMap map[Bytes]string, un
 // getBestUnitType determines the best unit type to use for formatting the
 // Bytes value based on the provided format options and the value itself. If a
 // forced unit type is specified in the format options, it will use that unit
-// regardless of the value. Otherwise, it will find the largest unit that is
-// less than or equal to the Bytes value to ensure that the formatted output is
-// human-readable and appropriately scaled.
+// regardless of the value. Otherwise, it will binary search the ascending
+// unitSlice for the largest unit that is less than or equal to the Bytes value
+// to ensure that the formatted output is human-readable and appropriately
+// scaled.
 func (b Bytes) getBestUnitType(formatOptions *formatOptions, unitSlice []Bytes) (bestUnit Bytes) {
-	if formatOptions.forcedUnitType != nil {
-		bestUnit = *formatOptions.forcedUnitType
-	} else {
-		// Find the best unit by finding the largest unit <= b
-		for _, unit := range unitSlice {
-			if Uint128(b).Cmp(Uint128(unit)) >= 0 {
-				bestUnit = unit
-				break
-			}
-		}
-		// If no unit was found (b is less than all units), use bytes
-		if bestUnit.Lo == 0 && bestUnit.Hi == 0 {
-			bestUnit = B
-		}
+	if formatOptions.forcedUnit != nil {
+		return formatOptions.forcedUnit.Factor
 	}
 
-	return bestUnit
+	i, found := slices.BinarySearchFunc(unitSlice, b, func(unit, target Bytes) int {
+		return Uint128(unit).CmpBytes(target)
+	})
+	if found {
+		return unitSlice[i]
+	}
+	// If no unit was found (b is less than all units), use bytes
+	if i == 0 {
+		return B
+	}
+	return unitSlice[i-1]
 }
@@ -1,7 +1,9 @@
 package bytesize
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 )
@@ -123,6 +125,84 @@ func TestIsValidUnit(t *testing.T) {
 	}
 }
 
+// TestUnitSystemOf tests classifying units by unit system
+func TestUnitSystemOf(t *testing.T) {
+	tests := []struct {
+		unit       string
+		wantSystem UnitSystem
+		wantOK     bool
+		wantSI     bool
+		wantIEC    bool
+	}{
+		{"KB", SI, true, true, false},
+		{"megabytes", SI, true, true, false},
+		{"qb", SI, true, true, false},
+		{"KiB", IEC, true, false, true},
+		{"mebibytes", IEC, true, false, true},
+		{"QiB", IEC, true, false, true},
+		{"B", SI, true, true, true},
+		{"bytes", SI, true, true, true},
+		{"k", 0, false, false, false},
+		{"Ki", 0, false, false, false},
+		{"", 0, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			system, ok := UnitSystemOf(tt.unit)
+			if system != tt.wantSystem || ok != tt.wantOK {
+				t.Errorf("UnitSystemOf(%q) = (%v, %v), want (%v, %v)", tt.unit, system, ok, tt.wantSystem, tt.wantOK)
+			}
+			if got := IsValidSIUnit(tt.unit); got != tt.wantSI {
+				t.Errorf("IsValidSIUnit(%q) = %v, want %v", tt.unit, got, tt.wantSI)
+			}
+			if got := IsValidIECUnit(tt.unit); got != tt.wantIEC {
+				t.Errorf("IsValidIECUnit(%q) = %v, want %v", tt.unit, got, tt.wantIEC)
+			}
+		})
+	}
+}
+
+// TestAppendLowerASCII tests the allocation-free ASCII lowercasing used by
+// the unit lookup
+func TestAppendLowerASCII(t *testing.T) {
+	tests := []struct {
+		input  string
+		capMax int
+		want   string
+		wantOK bool
+	}{
+		{"KiB", maxUnitLen, "kib", true},
+		{"QUETTABYTES", maxUnitLen, "quettabytes", true},
+		{"quettabytes!", maxUnitLen, "", false},
+		{"Mb", 1, "", false},
+		{"Ｍb", maxUnitLen, "", false},
+		{"", maxUnitLen, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := appendLowerASCII(make([]byte, 0, tt.capMax), tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("appendLowerASCII(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if ok && string(got) != tt.want {
+				t.Errorf("appendLowerASCII(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestUnitLookupAllocations tests that looking up an ASCII unit does not
+// allocate
+func TestUnitLookupAllocations(t *testing.T) {
+	for _, unit := range []string{"B", "MB", "GiB", " Kilobytes ", "QUETTABYTES"} {
+		if n := testing.AllocsPerRun(100, func() { IsValidUnit(unit) }); n != 0 {
+			t.Errorf("IsValidUnit(%q) allocated %v times, want 0", unit, n)
+		}
+	}
+}
+
 // TestParseBasicUnits tests parsing of basic byte units
 func TestParseBasicUnits(t *testing.T) {
 	tests := []struct {
@@ -501,6 +581,193 @@ func TestParseErrors(t *testing.T) {
 	}
 }
 
+// TestParseSyntaxErrors tests that malformed input is reported as a
+// *SyntaxError pointing at the offending byte
+func TestParseSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		msg    string
+	}{
+		{"1-2 MB", 1, "unexpected '-'"},
+		{"MB-1", 2, "unexpected '-'"},
+		{"--1 MB", 1, "unexpected '-'"},
+		{"1.2.3 KB", 3, "multiple decimal points"},
+		{"1 2 3 MB", 2, "unexpected '2'"},
+		{"10 MB 5", 6, "unexpected '5'"},
+		{"10 M B", 5, "unexpected 'B' after unit"},
+		{"- 5 MB", 1, "whitespace after sign"},
+		{"-MB", 1, "sign without digits"},
+		{"  -", 3, "sign without digits"},
+		{"5 MB.", 4, "unexpected '.'"},
+		{"5 ß1", 4, "unexpected '1'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("input=%q", tt.input), func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want a *SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Offset != tt.offset {
+				t.Errorf("Parse(%q) error offset = %d, want %d", tt.input, syntaxErr.Offset, tt.offset)
+			}
+			if syntaxErr.Input != tt.input {
+				t.Errorf("Parse(%q) error input = %q", tt.input, syntaxErr.Input)
+			}
+			if !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("Parse(%q) error = %v, expected to contain %q", tt.input, err, tt.msg)
+			}
+		})
+	}
+}
+
+// TestParseSigns tests parsing a leading plus sign and negative zero
+func TestParseSigns(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"+5 GB", Bytes{5_000_000_000, 0}, false},
+		{"  +1.5KiB", Bytes{1536, 0}, false},
+		{"+0 B", None, false},
+		{"-0 B", None, false},
+		{"-0.000 KB", None, false},
+		{"-5 GB", None, true},
+		{"-0.1 KB", None, true},
+		{"+ 5 GB", None, true},
+		{"+-5 GB", None, true},
+		{"++5 GB", None, true},
+		{"5+ GB", None, true},
+		{"+ GB", None, true},
+		{"+", None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestParseImplicitOne tests reading a bare unit as one of the unit
+func TestParseImplicitOne(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"GiB", GiB, false},
+		{"  MB ", MB, false},
+		{"kilobyte", KB, false},
+		{"2 GiB", times(GiB, 2), false},
+		{"B", B, false},
+		{"", None, true},
+		{"   ", None, true},
+		{"XB", None, true},
+		{"-GiB", None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, WithImplicitOne())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+
+	if _, err := Parse("GiB"); err == nil || !strings.Contains(err.Error(), "invalid number") {
+		t.Errorf("Parse(%q) without WithImplicitOne error = %v, want invalid number", "GiB", err)
+	}
+}
+
+// TestParseUint64 tests parsing sizes that must fit in a uint64
+func TestParseUint64(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      uint64
+		wantErr   bool
+		wantRange bool
+	}{
+		{"1 GiB", 1 << 30, false, false},
+		{"0 B", 0, false, false},
+		{"18446744073709551615 B", math.MaxUint64, false, false},
+		{"16 EiB", 0, true, true},
+		{"1 QB", 0, true, true},
+		{"lots", 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseUint64(tt.input)
+			if (err != nil) != tt.wantErr || errors.Is(err, ErrRange) != tt.wantRange {
+				t.Fatalf("ParseUint64(%q) error = %v, wantErr %v, wantRange %v", tt.input, err, tt.wantErr, tt.wantRange)
+			}
+			if got != tt.want {
+				t.Errorf("ParseUint64(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if got, err := ParseUint64("1.5 B", WithFractionalRounding(RoundCeil)); err != nil || got != 2 {
+		t.Errorf("ParseUint64(1.5 B, RoundCeil) = %d, %v, want 2", got, err)
+	}
+}
+
+// TestParseStrictSpacing tests that WithStrictSpacing accepts only a single
+// space between number and unit
+func TestParseStrictSpacing(t *testing.T) {
+	tests := []struct {
+		input      string
+		want       Bytes
+		wantOffset int
+	}{
+		{"10 MB", Bytes{10_000_000, 0}, -1},
+		{"1.5 KiB", Bytes{1536, 0}, -1},
+		{"+2 kilobytes", Bytes{2000, 0}, -1},
+		{"10MB", None, 2},
+		{"10  MB", None, 3},
+		{"10\tMB", None, 2},
+		{" 10 MB", None, 0},
+		{"10 MB ", None, 5},
+		{"10 MB\n", None, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, WithStrictSpacing())
+			if tt.wantOffset < 0 {
+				if err != nil || got != tt.want {
+					t.Errorf("Parse(%q) = %v, %v, want %v", tt.input, Uint128(got), err, Uint128(tt.want))
+				}
+				return
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want a *SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Offset != tt.wantOffset {
+				t.Errorf("Parse(%q) error offset = %d, want %d", tt.input, syntaxErr.Offset, tt.wantOffset)
+			}
+			if _, err := Parse(tt.input); err != nil {
+				t.Errorf("Parse(%q) without WithStrictSpacing error = %v", tt.input, err)
+			}
+		})
+	}
+}
+
 // TestParseBoundaryValues tests boundary conditions
 func TestParseBoundaryValues(t *testing.T) {
 	tests := []struct {
@@ -700,6 +967,59 @@ func BenchmarkParseError(b *testing.B) {
 	}
 }
 
+// BenchmarkParseFractionalScratch compares parsing a fractional value with a
+// fresh ratScratch, a pooled one, as Parse does, and a reused one, as
+// Decoder does
+func BenchmarkParseFractionalScratch(b *testing.B) {
+	opts, err := newParseOptions()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var sc ratScratch
+			parse("1.5 GiB", opts, &sc, nil)
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			sc := getRatScratch()
+			parse("1.5 GiB", opts, sc, nil)
+			putRatScratch(sc)
+		}
+	})
+
+	b.Run("reused", func(b *testing.B) {
+		b.ReportAllocs()
+		var sc ratScratch
+		for b.Loop() {
+			parse("1.5 GiB", opts, &sc, nil)
+		}
+	})
+}
+
+// TestParseAllocations tests that Parse takes its scratch values from the
+// pool rather than allocating new ones, which escape to the heap
+func TestParseAllocations(t *testing.T) {
+	opts, err := newParseOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh := testing.AllocsPerRun(100, func() {
+		var sc ratScratch
+		parse("1.5 GiB", opts, &sc, nil)
+	})
+	for _, input := range []string{"1.5 GiB", "10 MB"} {
+		if n := testing.AllocsPerRun(100, func() { Parse(input) }); n >= fresh {
+			t.Errorf("Parse(%q) allocated %v times, want fewer than %v", input, n, fresh)
+		}
+	}
+}
+
 // BenchmarkParseParallel benchmarks Parse function with parallel execution
 func BenchmarkParseParallel(b *testing.B) {
 	b.RunParallel(func(pb *testing.PB) {
@@ -709,6 +1029,51 @@ func BenchmarkParseParallel(b *testing.B) {
 	})
 }
 
+// TestParseScratchReuse tests that a ratScratch reused across parses of
+// different sizes, signs and rounding gives the same results as a fresh one
+func TestParseScratchReuse(t *testing.T) {
+	inputs := []string{
+		"1.5 GiB", "0.1 KB", "340282366920938463463374607431768211455 B",
+		"3.14159 KB", "10 MB", "-1 KB", "2.5 B", "0.000001 QiB", "7 bytes",
+	}
+
+	for _, mode := range []RoundingMode{RoundTruncate, RoundHalfUp, RoundCeil} {
+		opts, err := newParseOptions(WithFractionalRounding(mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var reused ratScratch
+		for _, input := range inputs {
+			var fresh ratScratch
+			want, wantExact, wantErr := parse(input, opts, &fresh, nil)
+			got, gotExact, gotErr := parse(input, opts, &reused, nil)
+			if got != want || gotExact != wantExact || (gotErr == nil) != (wantErr == nil) {
+				t.Errorf("%s: parse(%q) with reused scratch = (%v, %v, %v), want (%v, %v, %v)",
+					mode, input, Uint128(got), gotExact, gotErr, Uint128(want), wantExact, wantErr)
+			}
+		}
+	}
+}
+
+// TestBigMultipliers tests that the cached and uncached big forms of unit
+// multipliers match their Uint128 values
+func TestBigMultipliers(t *testing.T) {
+	units := append(decimalUnitScale[:], binaryUnitScale[:]...)
+	units = append(units, Bytes{12345, 0}, Bytes{7, 1}, Bytes(Max))
+
+	for _, unit := range units {
+		var sc ratScratch
+		bm := bigMultiplierOf(unit, &sc)
+		want := Uint128(unit).Big()
+		if bm.i.Cmp(want) != 0 {
+			t.Errorf("bigMultiplierOf(%v).i = %v", Uint128(unit), bm.i)
+		}
+		if !bm.r.IsInt() || bm.r.Num().Cmp(want) != 0 {
+			t.Errorf("bigMultiplierOf(%v).r = %v", Uint128(unit), bm.r)
+		}
+	}
+}
+
 func TestSet(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1074,6 +1439,333 @@ func TestFormatForcedUnit(t *testing.T) {
 	}
 }
 
+// TestFormatZero tests the options for formatting zero
+func TestFormatZero(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{"default", None, nil, "0.00 B"},
+		{"zero unit", None, []FormatOption{WithZeroUnit(GB)}, "0.00 GB"},
+		{"zero unit long", None, []FormatOption{WithZeroUnit(GiB), WithLongUnits(true)}, "0.00 Gibibytes"},
+		{"zero unit nonzero value", Bytes{1500, 0}, []FormatOption{WithZeroUnit(GB)}, "1.50 KB"},
+		{"zero unit over forced unit", None, []FormatOption{WithForcedUnit(MB), WithZeroUnit(GB)}, "0.00 GB"},
+		{"zero string", None, []FormatOption{WithZeroString("\u2014")}, "\u2014"},
+		{"empty zero string", None, []FormatOption{WithZeroString("")}, ""},
+		{"zero string over zero unit", None, []FormatOption{WithZeroUnit(GB), WithZeroString("-")}, "-"},
+		{"zero string kubernetes", None, []FormatOption{WithKubernetesSuffixes(), WithZeroString("-")}, "-"},
+		{"zero string nonzero value", B, []FormatOption{WithZeroString("-")}, "1.00 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.input.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+			f, err := NewFormatter(tt.opts...)
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			if got := string(f.AppendFormat(nil, tt.input)); got != tt.expected {
+				t.Errorf("AppendFormat() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := None.Format(WithZeroUnit(Bytes{3, 0})); err == nil {
+		t.Error("Format(WithZeroUnit(3 B)) returned no error")
+	}
+}
+
+// TestFormatPluralSuffix tests replacing the suffixes of long unit names
+func TestFormatPluralSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{"default one", KB, nil, "1.00 Kilobyte"},
+		{"default many", Bytes{2000, 0}, nil, "2.00 Kilobytes"},
+		{"parenthesized one", KB, []FormatOption{WithPluralSuffix("(s)", "(s)")}, "1.00 Kilobyte(s)"},
+		{"parenthesized many", Bytes{2000, 0}, []FormatOption{WithPluralSuffix("(s)", "(s)")}, "2.00 Kilobyte(s)"},
+		{"no suffix", Bytes{2000, 0}, []FormatOption{WithPluralSuffix("", "")}, "2.00 Kilobyte"},
+		{"bytes", Bytes{5, 0}, []FormatOption{WithPluralSuffix("", "n")}, "5.00 Byten"},
+		{"short units", Bytes{2000, 0}, []FormatOption{WithPluralSuffix("", "x"), WithLongUnits(false)}, "2.00 KB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.input.Format(append([]FormatOption{WithLongUnits(true)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatWordBytesBelowKB tests writing bytes as a word in short mode
+func TestFormatWordBytesBelowKB(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{"zero", None, nil, "0.00 bytes"},
+		{"one", B, nil, "1.00 byte"},
+		{"many", Bytes{999, 0}, nil, "999.00 bytes"},
+		{"kilobyte", KB, nil, "1.00 KB"},
+		{"binary", Bytes{1023, 0}, []FormatOption{WithDecimalUnits(false)}, "1023.00 bytes"},
+		{"whole", Bytes{2, 0}, []FormatOption{WithFormatString("%.0f %s")}, "2 bytes"},
+		{"forced unit", KB, []FormatOption{WithForcedUnit(B)}, "1000.00 bytes"},
+		{"plural suffix", Bytes{2, 0}, []FormatOption{WithPluralSuffix("(s)", "(s)")}, "2.00 byte(s)"},
+		{"long units", Bytes{2, 0}, []FormatOption{WithLongUnits(true)}, "2.00 Bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.input.Format(append([]FormatOption{WithWordBytesBelowKB()}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatSignificantDigits tests formatting with significant digits
+func TestFormatSignificantDigits(t *testing.T) {
+	tests := []struct {
+		input    Bytes
+		digits   int
+		expected string
+	}{
+		{Bytes{999, 0}, 3, "999 B"},
+		{Bytes{1024, 0}, 3, "1.02 KB"},
+		{Bytes{12_345_678_901, 0}, 3, "12.3 GB"},
+		{Bytes{123_456_789_012, 0}, 3, "123 GB"},
+		{TB, 2, "1.0 TB"},
+		{None, 3, "0 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			result, err := tt.input.Format(WithSignificantDigits(tt.digits))
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err := KB.Format(WithSignificantDigits(n)); err == nil {
+			t.Errorf("Format(WithSignificantDigits(%d)) returned no error", n)
+		}
+	}
+}
+
+// TestFormatUnitSymbols tests overriding the short names of units
+func TestFormatUnitSymbols(t *testing.T) {
+	octets := map[Bytes]string{B: "o", KB: "ko", MB: "Mo", GB: "Go"}
+	tests := []struct {
+		name     string
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{"octets GB", Bytes{1_500_000_000, 0}, []FormatOption{WithUnitSymbols(octets)}, "1.50 Go"},
+		{"octets B", Bytes{12, 0}, []FormatOption{WithUnitSymbols(octets)}, "12.00 o"},
+		{"missing unit", TB, []FormatOption{WithUnitSymbols(octets)}, "1.00 TB"},
+		{"forced unit", MB, []FormatOption{WithUnitSymbols(octets), WithForcedUnit(KB)}, "1000.00 ko"},
+		{"single letter", Bytes{1536, 0}, []FormatOption{WithUnitSymbols(map[Bytes]string{KB: "K"})}, "1.54 K"},
+		{"binary", Bytes{1536, 0}, []FormatOption{WithUnitSymbols(map[Bytes]string{KiB: "K"}), WithDecimalUnits(false)}, "1.50 K"},
+		{"long units", GB, []FormatOption{WithUnitSymbols(octets), WithLongUnits(true)}, "1.00 Gigabyte"},
+		{"over word bytes", Bytes{12, 0}, []FormatOption{WithUnitSymbols(octets), WithWordBytesBelowKB()}, "12.00 o"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.input.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatExactRounding tests that formatted values are rounded
+// half-to-even on the exact quotient rather than on a binary float, whose
+// error rounded values such as 1.015 down
+func TestFormatExactRounding(t *testing.T) {
+	tests := []struct {
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{Bytes{1015, 0}, nil, "1.02 KB"},
+		{Bytes{2675, 0}, nil, "2.68 KB"},
+		{Bytes{1025, 0}, nil, "1.02 KB"},
+		{Bytes{1005, 0}, nil, "1.00 KB"},
+		{Bytes{1035, 0}, nil, "1.04 KB"},
+		{Bytes(Max), []FormatOption{WithFormatString("%.30f %s")}, "340282366.920938463463374607431768211455 QB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			result, err := tt.input.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format(%v) = %q, want %q", Uint128(tt.input), result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatBitsOutput tests formatting the number of bits
+func TestFormatBitsOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{"megabits", times(MB, 100), nil, "800.00 Mb"},
+		{"kilobit", Bytes{125, 0}, nil, "1.00 Kb"},
+		{"bits", Bytes{100, 0}, nil, "800.00 b"},
+		{"binary", MiB, []FormatOption{WithDecimalUnits(false)}, "8.00 Mib"},
+		{"long", times(MB, 100), []FormatOption{WithLongUnits(true)}, "800.00 Megabits"},
+		{"long bit", Bytes{1, 0}, []FormatOption{WithLongUnits(true)}, "8.00 Bits"},
+		{"word bits", Bytes{1, 0}, []FormatOption{WithWordBytesBelowKB()}, "8.00 bits"},
+		{"forced unit", GB, []FormatOption{WithForcedUnit(MB)}, "8000.00 Mb"},
+		{"max", Bytes(Max), nil, "2722258935.37 Qb"},
+		{"max in bits", Bytes(Max), []FormatOption{WithForcedUnit(B)}, "2722258935367507707706996859454145691640.00 b"},
+		{"max in bits to 3 digits", Bytes(Max), []FormatOption{WithForcedUnit(B), WithSignificantDigits(3)}, "2722258935367507707706996859454145691640 b"},
+		{"zero", None, nil, "0.00 b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.input.Format(append(tt.opts, WithBitsOutput())...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatNonBreakingSpace tests replacing spaces with no-break spaces
+func TestFormatNonBreakingSpace(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []FormatOption
+		expected string
+	}{
+		{"no-break space", []FormatOption{WithNonBreakingSpace()}, "1.50\u00a0KB"},
+		{"narrow no-break space", []FormatOption{WithNarrowNonBreakingSpace()}, "1.50\u202fKB"},
+		{"last wins", []FormatOption{WithNarrowNonBreakingSpace(), WithNonBreakingSpace()}, "1.50\u00a0KB"},
+		{"padding", []FormatOption{WithNonBreakingSpace(), WithFormatString("%6.1f %s")}, "\u00a0\u00a0\u00a01.5\u00a0KB"},
+		{"long units", []FormatOption{WithNonBreakingSpace(), WithLongUnits(true)}, "1.50\u00a0Kilobytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Bytes{1500, 0}
+			result, err := b.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+			f, err := NewFormatter(tt.opts...)
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			if got := string(f.AppendFormat([]byte("size: "), b)); got != "size: "+tt.expected {
+				t.Errorf("AppendFormat() = %q, want %q", got, "size: "+tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatColorizer tests wrapping the output for its value and unit
+func TestFormatColorizer(t *testing.T) {
+	red := func(value float64, unit string) (string, string) {
+		if unit == "GB" && value >= 100 || unit == "TB" {
+			return "\x1b[31m", "\x1b[0m"
+		}
+		return "", ""
+	}
+	tests := []struct {
+		name     string
+		input    Bytes
+		opts     []FormatOption
+		expected string
+	}{
+		{"below threshold", times(GB, 99), nil, "99.00 GB"},
+		{"at threshold", times(GB, 100), nil, "\x1b[31m100.00 GB\x1b[0m"},
+		{"larger unit", Bytes{1_500_000_000_000, 0}, nil, "\x1b[31m1.50 TB\x1b[0m"},
+		{"no-break space", times(GB, 100), []FormatOption{WithNonBreakingSpace()}, "\x1b[31m100.00\u00a0GB\x1b[0m"},
+		{"kubernetes", times(GB, 100), []FormatOption{WithKubernetesSuffixes()}, "100G"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]FormatOption{WithColorizer(red)}, tt.opts...)
+			result, err := tt.input.Format(opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+			f, err := NewFormatter(opts...)
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			if got := string(f.AppendFormat([]byte("size: "), tt.input)); got != "size: "+tt.expected {
+				t.Errorf("AppendFormat() = %q, want %q", got, "size: "+tt.expected)
+			}
+		})
+	}
+
+	var gotValue float64
+	var gotUnit string
+	_, err := Bytes{1536, 0}.Format(WithDecimalUnits(false), WithColorizer(func(value float64, unit string) (string, string) {
+		gotValue, gotUnit = value, unit
+		return "", ""
+	}))
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if gotValue != 1.5 || gotUnit != "KiB" {
+		t.Errorf("colorizer called with %v, %q, want 1.5, \"KiB\"", gotValue, gotUnit)
+	}
+}
+
 // TestFormatCombinedOptions tests using multiple format options together
 func TestFormatCombinedOptions(t *testing.T) {
 	tests := []struct {
@@ -1197,6 +1889,34 @@ func TestFormatAutoUnitSelection(t *testing.T) {
 	}
 }
 
+// TestGetBestUnitTypeBoundaries tests unit selection on either side of
+// every unit boundary
+func TestGetBestUnitTypeBoundaries(t *testing.T) {
+	for _, decimal := range []bool{true, false} {
+		opts := newFormatOptions()
+		opts.decimalUnits = decimal
+		_, units := getUnitMappings(opts)
+		for i, unit := range units {
+			if got := unit.getBestUnitType(opts, units); got != unit {
+				t.Errorf("getBestUnitType(%v) = %v, want %v", Uint128(unit), Uint128(got), Uint128(unit))
+			}
+			if i == 0 {
+				continue
+			}
+			below := Bytes(Uint128(unit).Sub64(1))
+			if got := below.getBestUnitType(opts, units); got != units[i-1] {
+				t.Errorf("getBestUnitType(%v) = %v, want %v", Uint128(below), Uint128(got), Uint128(units[i-1]))
+			}
+		}
+		if got := None.getBestUnitType(opts, units); got != B {
+			t.Errorf("getBestUnitType(0) = %v, want 1", Uint128(got))
+		}
+		if got := Bytes(Max).getBestUnitType(opts, units); got != units[len(units)-1] {
+			t.Errorf("getBestUnitType(Max) = %v, want %v", Uint128(got), Uint128(units[len(units)-1]))
+		}
+	}
+}
+
 // TestFormatPluralization tests correct pluralization of unit names
 func TestFormatPluralization(t *testing.T) {
 	tests := []struct {
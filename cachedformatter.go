@@ -0,0 +1,93 @@
+package bytesize
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// CachedFormatter is a Formatter that remembers the strings of the values
+// it formatted most recently, for pages that format the same few values
+// thousands of times. When full, it evicts the least recently used value.
+// A CachedFormatter is safe for concurrent use.
+type CachedFormatter struct {
+	f        *Formatter
+	capacity int
+
+	mu      sync.Mutex
+	entries map[Bytes]*list.Element // of *cacheEntry
+	lru     list.List               // most recently used first
+	hits    uint64
+	misses  uint64
+}
+
+type cacheEntry struct {
+	b Bytes
+	s string
+}
+
+// NewCachedFormatter returns a CachedFormatter that formats with f and
+// remembers up to capacity values. It returns an error if capacity is not
+// positive.
+func NewCachedFormatter(f *Formatter, capacity int) (*CachedFormatter, error) {
+	if capacity < 1 {
+		return nil, fmt.Errorf("invalid cache capacity: %d", capacity)
+	}
+	return &CachedFormatter{f: f, capacity: capacity, entries: make(map[Bytes]*list.Element, capacity)}, nil
+}
+
+// Format formats b as Formatter.Format does, returning the remembered
+// string if b was formatted recently.
+func (c *CachedFormatter) Format(b Bytes) string {
+	c.mu.Lock()
+	if e, ok := c.entries[b]; ok {
+		c.lru.MoveToFront(e)
+		c.hits++
+		s := e.Value.(*cacheEntry).s
+		c.mu.Unlock()
+		return s
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Format outside the lock; a value formatted by two goroutines at
+	// once is stored once
+	s := c.f.Format(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[b]; ok {
+		return s
+	}
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).b)
+	}
+	c.entries[b] = c.lru.PushFront(&cacheEntry{b: b, s: s})
+	return s
+}
+
+// Len returns the number of values remembered.
+func (c *CachedFormatter) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns the number of calls to Format that found their value
+// remembered and that did not.
+func (c *CachedFormatter) Stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Reset forgets every remembered value and zeroes the statistics.
+func (c *CachedFormatter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.lru.Init()
+	c.hits, c.misses = 0, 0
+}
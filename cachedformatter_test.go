@@ -0,0 +1,103 @@
+package bytesize
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestCachedFormatter tests that a CachedFormatter formats like its
+// Formatter and evicts the least recently used value
+func TestCachedFormatter(t *testing.T) {
+	f, err := NewFormatter(WithDecimalUnits(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewCachedFormatter(f, 2)
+	if err != nil {
+		t.Fatalf("NewCachedFormatter() error = %v", err)
+	}
+
+	for _, b := range []Bytes{KiB, MiB, KiB, GiB, KiB, MiB} {
+		if got, want := c.Format(b), f.Format(b); got != want {
+			t.Errorf("Format(%v) = %q, want %q", Uint128(b), got, want)
+		}
+	}
+	// KiB and MiB miss, KiB hits, GiB misses and evicts MiB, KiB hits and
+	// MiB misses and evicts GiB
+	if hits, misses := c.Stats(); hits != 2 || misses != 4 {
+		t.Errorf("Stats() = %d, %d, want 2, 4", hits, misses)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+
+	c.Reset()
+	if hits, misses := c.Stats(); c.Len() != 0 || hits != 0 || misses != 0 {
+		t.Errorf("after Reset() Len() = %d, Stats() = %d, %d", c.Len(), hits, misses)
+	}
+	if got := c.Format(KiB); got != "1.00 KiB" {
+		t.Errorf("Format(KiB) after Reset() = %q", got)
+	}
+}
+
+// TestCachedFormatterCapacity tests that the capacity must be positive
+func TestCachedFormatterCapacity(t *testing.T) {
+	f, _ := NewFormatter()
+	for _, capacity := range []int{0, -1} {
+		if _, err := NewCachedFormatter(f, capacity); err == nil {
+			t.Errorf("NewCachedFormatter(%d) returned no error", capacity)
+		}
+	}
+}
+
+// TestCachedFormatterConcurrent tests concurrent use, under -race
+func TestCachedFormatterConcurrent(t *testing.T) {
+	f, _ := NewFormatter()
+	c, _ := NewCachedFormatter(f, 8)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				b := Bytes{uint64((g + i) % 16), 0}
+				if got, want := c.Format(b), f.Format(b); got != want {
+					t.Errorf("Format(%v) = %q, want %q", Uint128(b), got, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if c.Len() > 8 {
+		t.Errorf("Len() = %d, want at most 8", c.Len())
+	}
+}
+
+func BenchmarkCachedFormatterHit(b *testing.B) {
+	f, _ := NewFormatter()
+	c, _ := NewCachedFormatter(f, 16)
+	values := []Bytes{KB, MB, GB, Bytes{1536, 0}}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		c.Format(values[i%len(values)])
+	}
+}
+
+func BenchmarkCachedFormatterMiss(b *testing.B) {
+	f, _ := NewFormatter()
+	c, _ := NewCachedFormatter(f, 16)
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		c.Format(Bytes{uint64(i), 0})
+	}
+}
+
+func BenchmarkFormatterUncached(b *testing.B) {
+	f, _ := NewFormatter()
+	values := []Bytes{KB, MB, GB, Bytes{1536, 0}}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		f.Format(values[i%len(values)])
+	}
+}
@@ -0,0 +1,13 @@
+package bytesize
+
+// Canonicalize parses s and formats the result with opts, rewriting any
+// size string into one consistent form, e.g. "2048 megabytes" to
+// "2.05 GB". It returns the parse error if s is not a valid size, or an
+// error if any of the options are invalid.
+func Canonicalize(s string, opts ...FormatOption) (string, error) {
+	b, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return b.Format(opts...)
+}
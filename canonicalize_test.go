@@ -0,0 +1,41 @@
+package bytesize
+
+import "testing"
+
+// TestCanonicalize tests rewriting size strings into one form
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  []FormatOption
+		want  string
+	}{
+		{"2048 megabytes", nil, "2.05 GB"},
+		{"  2048MB ", nil, "2.05 GB"},
+		{"2.048 GB", nil, "2.05 GB"},
+		{"1024 KiB", []FormatOption{WithDecimalUnits(false)}, "1.00 MiB"},
+		{"1.5 gibibytes", []FormatOption{WithForcedUnit(B), WithFormatString("%.0f %s")}, "1610612736 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Canonicalize(tt.input, tt.opts...)
+			if err != nil {
+				t.Fatalf("Canonicalize(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Canonicalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCanonicalizeErrors tests that Canonicalize reports parse and option
+// errors
+func TestCanonicalizeErrors(t *testing.T) {
+	if _, err := Canonicalize("12 XB"); err == nil {
+		t.Error("Canonicalize(\"12 XB\") returned no error")
+	}
+	if _, err := Canonicalize("12 MB", WithFormatString("")); err == nil {
+		t.Error("Canonicalize() with an empty format string returned no error")
+	}
+}
@@ -0,0 +1,62 @@
+package bytesize
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrNeverReached is returned by TimeToReach when a limit above the current
+// size is never reached because the growth rate is zero.
+var ErrNeverReached = errors.New("limit is never reached")
+
+// Project returns the size that current grows to over horizon at the
+// constant rate growth, for capacity planning such as forecasting disk
+// usage a quarter ahead. Any fraction of a byte is truncated. It returns an
+// error if growth.Per is not positive, if horizon is negative, or if the
+// result overflows.
+func Project(current Bytes, growth Rate, horizon time.Duration) (Bytes, error) {
+	if growth.Per <= 0 {
+		return Bytes{}, fmt.Errorf("invalid rate period: %v", growth.Per)
+	}
+	if horizon < 0 {
+		return Bytes{}, fmt.Errorf("invalid horizon: %v", horizon)
+	}
+
+	// growth.Amount × horizon can exceed 128 bits even when the result
+	// does not
+	grown := new(big.Int).Mul(Uint128(growth.Amount).Big(), big.NewInt(int64(horizon)))
+	grown.Quo(grown, big.NewInt(int64(growth.Per)))
+	g, err := FromBigErr(grown)
+	if err != nil {
+		return Bytes{}, fmt.Errorf("value overflows Uint128: growth is %d bits", grown.BitLen())
+	}
+	sum, err := Uint128(current).AddErr(g)
+	if err != nil {
+		return Bytes{}, err
+	}
+	return Bytes(sum), nil
+}
+
+// TimeToReach returns how long current takes to grow to limit at the
+// constant rate growth, rounded up to a whole nanosecond, such as the time
+// until a disk is full. It returns zero if current has already reached
+// limit, ErrNeverReached if growth is zero, and an error if growth.Per is
+// not positive or the duration does not fit in a time.Duration.
+func TimeToReach(current, limit Bytes, growth Rate) (time.Duration, error) {
+	if growth.Per <= 0 {
+		return 0, fmt.Errorf("invalid rate period: %v", growth.Per)
+	}
+	if Uint128(current).CmpBytes(limit) >= 0 {
+		return 0, nil
+	}
+	if Uint128(growth.Amount).IsZero() {
+		return 0, ErrNeverReached
+	}
+	d, ok := growth.durationOf(Bytes(Uint128(limit).SubBytes(current)))
+	if !ok {
+		return 0, fmt.Errorf("time to reach %s at %s overflows time.Duration", limit, growth)
+	}
+	return d, nil
+}
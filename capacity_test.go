@@ -0,0 +1,105 @@
+package bytesize
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestProject tests projecting sizes forward at a growth rate
+func TestProject(t *testing.T) {
+	tests := []struct {
+		current Bytes
+		growth  Rate
+		horizon time.Duration
+		want    Bytes
+	}{
+		{GB, PerSecond(MB), time.Second, Bytes{1_001_000_000, 0}},
+		{GB, PerSecond(MB), 0, GB},
+		{None, Rate{GB, 24 * time.Hour}, 30 * 24 * time.Hour, times(GB, 30)},
+		{TB, Rate{times(GB, 10), 24 * time.Hour}, 12 * time.Hour, Bytes{1_005_000_000_000, 0}},
+		{None, Rate{Bytes{3, 0}, time.Hour}, 20 * time.Minute, Bytes{1, 0}},
+		{None, Rate{Bytes{3, 0}, time.Hour}, 19 * time.Minute, None},
+		{GB, PerSecond(None), time.Hour, GB},
+		{None, Rate{Bytes(Uint128(Max).Rsh(1)), time.Hour}, time.Hour, Bytes(Uint128(Max).Rsh(1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.growth.String()+"/"+tt.horizon.String(), func(t *testing.T) {
+			got, err := Project(tt.current, tt.growth, tt.horizon)
+			if err != nil {
+				t.Fatalf("Project() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Project() = %v, want %v", Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestProjectErrors tests that invalid rates and horizons and overflows
+// are rejected
+func TestProjectErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		current Bytes
+		growth  Rate
+		horizon time.Duration
+	}{
+		{"zero period", GB, Rate{MB, 0}, time.Hour},
+		{"negative period", GB, Rate{MB, -time.Second}, time.Hour},
+		{"negative horizon", GB, PerSecond(MB), -time.Hour},
+		{"overflow", Bytes(Max), PerSecond(B), time.Second},
+		{"overflowing growth", None, PerSecond(Bytes(Max)), time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Project(tt.current, tt.growth, tt.horizon); err == nil {
+				t.Errorf("Project() should have errored")
+			}
+		})
+	}
+}
+
+// TestTimeToReach tests how long sizes take to grow to a limit
+func TestTimeToReach(t *testing.T) {
+	tests := []struct {
+		name           string
+		current, limit Bytes
+		growth         Rate
+		want           time.Duration
+	}{
+		{"one second", GB, Bytes{1_001_000_000, 0}, PerSecond(MB), time.Second},
+		{"days", Bytes{400 * 1_000_000_000, 0}, TB, Rate{times(GB, 10), 24 * time.Hour}, 60 * 24 * time.Hour},
+		{"rounded up", None, Bytes{1, 0}, Rate{Bytes{3, 0}, time.Nanosecond}, time.Nanosecond},
+		{"reached", TB, GB, PerSecond(MB), 0},
+		{"equal", TB, TB, PerSecond(None), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TimeToReach(tt.current, tt.limit, tt.growth)
+			if err != nil {
+				t.Fatalf("TimeToReach() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TimeToReach() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTimeToReachErrors tests that limits that are never reached or are
+// too far away are reported
+func TestTimeToReachErrors(t *testing.T) {
+	if _, err := TimeToReach(GB, TB, PerSecond(None)); !errors.Is(err, ErrNeverReached) {
+		t.Errorf("TimeToReach() with zero growth error = %v, want ErrNeverReached", err)
+	}
+	if _, err := TimeToReach(GB, TB, Rate{MB, 0}); err == nil {
+		t.Errorf("TimeToReach() with zero period should have errored")
+	}
+	if _, err := TimeToReach(None, Bytes(Max), PerSecond(B)); err == nil {
+		t.Errorf("TimeToReach() overflowing time.Duration should have errored")
+	}
+}
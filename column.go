@@ -0,0 +1,57 @@
+package bytesize
+
+import (
+	"math"
+	"strconv"
+)
+
+// Int64Column returns b as an int64 for storage in a signed 64-bit column
+// such as a SQL BIGINT. It returns a *RangeError if b exceeds
+// math.MaxInt64, rather than silently truncating it.
+func (b Bytes) Int64Column() (int64, error) {
+	if Uint128(b).Hi != 0 || Uint128(b).Lo > math.MaxInt64 {
+		return 0, &RangeError{Func: "Int64Column", Value: Uint128(b).String()}
+	}
+	return int64(Uint128(b).Lo), nil
+}
+
+// FromInt64Column returns the size stored in a signed 64-bit column by
+// Int64Column. It returns a *RangeError if v is negative.
+func FromInt64Column(v int64) (Bytes, error) {
+	if v < 0 {
+		return Bytes{}, &RangeError{Func: "FromInt64Column", Value: strconv.FormatInt(v, 10)}
+	}
+	return Bytes{uint64(v), 0}, nil
+}
+
+// maxDecimal128 is 10^38, the smallest value with more digits than a
+// Decimal128's maximum precision of 38.
+var maxDecimal128 = Uint128{0x098A224000000000, 0x4B3B4CA85A86C47A}
+
+// ToDecimal128 returns b in the layout of an Arrow or Parquet Decimal128
+// with scale 0, a signed 128-bit two's complement integer split into its
+// high and low 64 bits, for analytics pipelines that persist exact sizes.
+// It returns a *RangeError if b is 10^38 or more, which does not fit in a
+// Decimal128's maximum precision of 38 digits.
+func (b Bytes) ToDecimal128() (hi int64, lo uint64, err error) {
+	if Uint128(b).Cmp(maxDecimal128) >= 0 {
+		return 0, 0, &RangeError{Func: "ToDecimal128", Value: Uint128(b).String()}
+	}
+	return int64(Uint128(b).Hi), Uint128(b).Lo, nil
+}
+
+// FromDecimal128 returns the size stored in the Decimal128 layout by
+// ToDecimal128. It returns a *RangeError if the value is negative.
+func FromDecimal128(hi int64, lo uint64) (Bytes, error) {
+	if hi < 0 {
+		return Bytes{}, &RangeError{Func: "FromDecimal128", Value: negativeDecimal128String(hi, lo)}
+	}
+	return Bytes{lo, uint64(hi)}, nil
+}
+
+// negativeDecimal128String returns the negative signed 128-bit value hi,
+// lo in base 10.
+func negativeDecimal128String(hi int64, lo uint64) string {
+	// Negate the two's complement value
+	return "-" + Uint128{^lo, ^uint64(hi)}.AddWrap64(1).String()
+}
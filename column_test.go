@@ -0,0 +1,123 @@
+package bytesize
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestInt64Column tests converting sizes to int64 columns
+func TestInt64Column(t *testing.T) {
+	tests := []struct {
+		input   Bytes
+		want    int64
+		wantErr string
+	}{
+		{None, 0, ""},
+		{GB, 1_000_000_000, ""},
+		{Bytes{math.MaxInt64, 0}, math.MaxInt64, ""},
+		{Bytes{math.MaxInt64 + 1, 0}, 0, "bytesize.Int64Column: 9223372036854775808: value out of range"},
+		{QiB, 0, "bytesize.Int64Column: 1267650600228229401496703205376: value out of range"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.input.Int64Column()
+		if tt.wantErr != "" {
+			var rangeErr *RangeError
+			if !errors.As(err, &rangeErr) || !errors.Is(err, ErrRange) || err.Error() != tt.wantErr {
+				t.Errorf("Int64Column(%v) error = %v, want %s", Uint128(tt.input), err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Int64Column(%v) = %d, %v, want %d", Uint128(tt.input), got, err, tt.want)
+		}
+	}
+}
+
+// TestFromInt64Column tests converting int64 columns to sizes
+func TestFromInt64Column(t *testing.T) {
+	for _, v := range []int64{0, 1, 1024, math.MaxInt64} {
+		b, err := FromInt64Column(v)
+		if err != nil {
+			t.Fatalf("FromInt64Column(%d) error = %v", v, err)
+		}
+		if got, err := b.Int64Column(); err != nil || got != v {
+			t.Errorf("FromInt64Column(%d).Int64Column() = %d, %v", v, got, err)
+		}
+	}
+
+	for _, v := range []int64{-1, math.MinInt64} {
+		if _, err := FromInt64Column(v); !errors.Is(err, ErrRange) {
+			t.Errorf("FromInt64Column(%d) error = %v, want ErrRange", v, err)
+		}
+	}
+}
+
+// TestDecimal128 tests round trips through the Decimal128 layout
+func TestDecimal128(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  Bytes
+		hi     int64
+		lo     uint64
+		wantOK bool
+	}{
+		{"zero", None, 0, 0, true},
+		{"gigabyte", GB, 0, 1_000_000_000, true},
+		{"2^64", Bytes{0, 1}, 1, 0, true},
+		{"10^38 - 1", Bytes{0x098A223FFFFFFFFF, 0x4B3B4CA85A86C47A}, 0x4B3B4CA85A86C47A, 0x098A223FFFFFFFFF, true},
+		{"10^38", Bytes{0x098A224000000000, 0x4B3B4CA85A86C47A}, 0, 0, false},
+		{"2^127 - 1", Bytes{math.MaxUint64, math.MaxInt64}, 0, 0, false},
+		{"2^127", Bytes{0, 1 << 63}, 0, 0, false},
+		{"max", Bytes(Max), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hi, lo, err := tt.input.ToDecimal128()
+			if !tt.wantOK {
+				if !errors.Is(err, ErrRange) {
+					t.Fatalf("ToDecimal128() error = %v, want ErrRange", err)
+				}
+				return
+			}
+			if err != nil || hi != tt.hi || lo != tt.lo {
+				t.Fatalf("ToDecimal128() = %d, %d, %v, want %d, %d", hi, lo, err, tt.hi, tt.lo)
+			}
+			got, err := FromDecimal128(hi, lo)
+			if err != nil || got != tt.input {
+				t.Errorf("FromDecimal128(%d, %d) = %v, %v, want %v", hi, lo, Uint128(got), err, Uint128(tt.input))
+			}
+		})
+	}
+}
+
+// TestMaxDecimal128 tests that the Decimal128 limit is 10^38
+func TestMaxDecimal128(t *testing.T) {
+	if got, want := maxDecimal128.String(), "1"+strings.Repeat("0", 38); got != want {
+		t.Errorf("maxDecimal128 = %s, want %s", got, want)
+	}
+}
+
+// TestFromDecimal128Negative tests that negative Decimal128 values are
+// out of range
+func TestFromDecimal128Negative(t *testing.T) {
+	tests := []struct {
+		hi   int64
+		lo   uint64
+		want string
+	}{
+		{-1, math.MaxUint64, "bytesize.FromDecimal128: -1: value out of range"},
+		{-1, 0, "bytesize.FromDecimal128: -18446744073709551616: value out of range"},
+		{math.MinInt64, 0, "bytesize.FromDecimal128: -170141183460469231731687303715884105728: value out of range"},
+	}
+
+	for _, tt := range tests {
+		_, err := FromDecimal128(tt.hi, tt.lo)
+		if !errors.Is(err, ErrRange) || err.Error() != tt.want {
+			t.Errorf("FromDecimal128(%d, %d) error = %v, want %s", tt.hi, tt.lo, err, tt.want)
+		}
+	}
+}
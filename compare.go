@@ -0,0 +1,68 @@
+package bytesize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// absDiff returns |a - b|.
+func absDiff(a, b Bytes) Uint128 {
+	if Uint128(a).CmpBytes(b) < 0 {
+		a, b = b, a
+	}
+	return Uint128(a).SubBytes(b)
+}
+
+// WithinAbs reports whether b and other differ by at most delta bytes.
+func (b Bytes) WithinAbs(other, delta Bytes) bool {
+	return absDiff(b, other).CmpBytes(delta) <= 0
+}
+
+// ApproxEqual reports whether b and other differ by at most tolerance times
+// the larger of the two, so that a tolerance of 0.01 accepts sizes within
+// 1% of each other. The comparison is exact. A tolerance of zero requires
+// equality, and a negative or NaN tolerance never matches.
+func (b Bytes) ApproxEqual(other Bytes, tolerance float64) bool {
+	if math.IsNaN(tolerance) || tolerance < 0 {
+		return false
+	}
+	if math.IsInf(tolerance, 1) {
+		return true
+	}
+
+	larger := b
+	if Uint128(other).CmpBytes(b) > 0 {
+		larger = other
+	}
+
+	// A float64 has a 53-bit mantissa and larger at most 128 bits, so
+	// their product is exact at this precision
+	const prec = 53 + 128
+	limit := new(big.Float).SetPrec(prec).SetFloat64(tolerance)
+	limit.Mul(limit, new(big.Float).SetPrec(prec).SetInt(Uint128(larger).Big()))
+	diff := new(big.Float).SetPrec(prec).SetInt(absDiff(b, other).Big())
+	return diff.Cmp(limit) <= 0
+}
+
+// SameCount reports whether a and b, parsed like Parse, are exactly the
+// same number of bytes, however each is written: "1 GiB" and
+// "1073741824 B" are the same count, while "1 GiB" and "1 GB" are not. It
+// returns an error if either does not parse.
+func SameCount(a, b string, opts ...ParseOption) (bool, error) {
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return false, err
+	}
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	x, _, err := parse(a, parseOptions, sc, nil)
+	if err != nil {
+		return false, fmt.Errorf("first size: %w", err)
+	}
+	y, _, err := parse(b, parseOptions, sc, nil)
+	if err != nil {
+		return false, fmt.Errorf("second size: %w", err)
+	}
+	return x == y, nil
+}
@@ -0,0 +1,102 @@
+package bytesize
+
+import (
+	"math"
+	"testing"
+)
+
+// TestWithinAbs tests comparing sizes within an absolute delta
+func TestWithinAbs(t *testing.T) {
+	tests := []struct {
+		a, b, delta Bytes
+		want        bool
+	}{
+		{KB, KB, None, true},
+		{KB, Bytes{1001, 0}, One, true},
+		{Bytes{1001, 0}, KB, One, true},
+		{KB, Bytes{1002, 0}, One, false},
+		{None, Bytes(Max), Bytes(Max), true},
+		{Bytes(Max), None, Bytes(Uint128(Max).Sub64(1)), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.WithinAbs(tt.b, tt.delta); got != tt.want {
+			t.Errorf("%v.WithinAbs(%v, %v) = %v, want %v", Uint128(tt.a), Uint128(tt.b), Uint128(tt.delta), got, tt.want)
+		}
+	}
+}
+
+// TestApproxEqual tests comparing sizes within a relative tolerance
+func TestApproxEqual(t *testing.T) {
+	tests := []struct {
+		a, b      Bytes
+		tolerance float64
+		want      bool
+	}{
+		{GB, GB, 0, true},
+		{GB, Bytes{1_000_000_001, 0}, 0, false},
+		{Bytes{990, 0}, KB, 0.01, true},
+		{KB, Bytes{990, 0}, 0.01, true},
+		{Bytes{989, 0}, KB, 0.01, false},
+		{GiB, GB, 0.07, true},
+		{GiB, GB, 0.06, false},
+		{None, None, 0, true},
+		{None, One, 0.5, false},
+		{None, One, 1, true},
+		{Bytes(Max), Bytes(Uint128(Max).Sub64(1)), 1e-38, true},
+		{Bytes(Max), Bytes(Uint128(Max).Sub64(1)), 1e-39, false},
+		{KB, MB, math.Inf(1), true},
+		{KB, KB, -0.1, false},
+		{KB, KB, math.NaN(), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.ApproxEqual(tt.b, tt.tolerance); got != tt.want {
+			t.Errorf("%v.ApproxEqual(%v, %g) = %v, want %v", Uint128(tt.a), Uint128(tt.b), tt.tolerance, got, tt.want)
+		}
+	}
+}
+
+// TestSameCount tests comparing sizes written in different units
+func TestSameCount(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    bool
+		wantErr bool
+	}{
+		{"1 GiB", "1073741824 B", true, false},
+		{"1 GiB", "1024 MiB", true, false},
+		{"1 GiB", "1 GB", false, false},
+		{"1.5 KB", "1500 bytes", true, false},
+		{"0.5 MiB", "512 KiB", true, false},
+		{"1 MB", "1000 KB", true, false},
+		{"1 MB", "1 MiB", false, false},
+		{"0 B", "0 QiB", true, false},
+		{"1 GiB", "1 gigglebyte", false, true},
+		{"", "1 B", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"="+tt.b, func(t *testing.T) {
+			got, err := SameCount(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SameCount(%q, %q) error = %v, wantErr %v", tt.a, tt.b, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SameCount(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSameCountOptions tests that SameCount applies the parse options to
+// both sizes
+func TestSameCountOptions(t *testing.T) {
+	got, err := SameCount("1Gi", "1 GiB", WithKubernetesSuffixParsing())
+	if err != nil || !got {
+		t.Errorf("SameCount() = %v, %v, want true, nil", got, err)
+	}
+	if _, err := SameCount("1 B", "1 B", WithFractionalRounding(RoundingMode(42))); err == nil {
+		t.Errorf("SameCount() with RoundingMode(42) should have errored")
+	}
+}
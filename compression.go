@@ -0,0 +1,50 @@
+package bytesize
+
+import "fmt"
+
+// Ratio returns the compression ratio of original to compressed, e.g. 2.5
+// for 2.5:1 when 10 MB compresses to 4 MB. A ratio below 1 means the data
+// grew. It returns an error if compressed is zero.
+func Ratio(compressed, original Bytes) (float64, error) {
+	if Uint128(compressed).IsZero() {
+		return 0, fmt.Errorf("compression ratio of zero bytes")
+	}
+	f, _ := percentRat(original, compressed).Float64()
+	return f / 100, nil
+}
+
+// Savings returns how much smaller compressed is than original, as a Delta
+// from compressed to original, and as a percentage of original. Both are
+// negative if the data grew. It returns an error if original is zero.
+func Savings(compressed, original Bytes) (Delta, float64, error) {
+	saved := Diff(compressed, original)
+	percent, err := Percent(saved.Magnitude, original)
+	if err != nil {
+		return Delta{}, 0, err
+	}
+	if saved.Negative {
+		percent = -percent
+	}
+	return saved, percent, nil
+}
+
+// FormatSavings returns the standard report line for compressing original
+// to compressed, "saved 1.20 GiB (38%)", or "grew 1.00 KB (2%)" if the data
+// grew, with the size formatted with opts and the percentage rounded to a
+// whole number. It returns an error if original is zero or any of opts are
+// invalid.
+func FormatSavings(compressed, original Bytes, opts ...FormatOption) (string, error) {
+	if Uint128(original).IsZero() {
+		return "", fmt.Errorf("savings of zero bytes")
+	}
+	saved := Diff(compressed, original)
+	size, err := saved.Magnitude.Format(opts...)
+	if err != nil {
+		return "", err
+	}
+	verb := "saved"
+	if saved.Negative {
+		verb = "grew"
+	}
+	return fmt.Sprintf("%s %s (%s)", verb, size, FormatPercent(saved.Magnitude, original, 0)), nil
+}
@@ -0,0 +1,71 @@
+package bytesize
+
+import "testing"
+
+// TestRatio tests compression ratios
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		compressed, original Bytes
+		want                 float64
+		wantErr              bool
+	}{
+		{times(MB, 4), times(MB, 10), 2.5, false},
+		{MB, MB, 1, false},
+		{times(MB, 2), MB, 0.5, false},
+		{None, MB, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := Ratio(tt.compressed, tt.original)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("Ratio(%v, %v) error = %v, wantErr %v", tt.compressed, tt.original, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("Ratio(%v, %v) = %v, want %v", tt.compressed, tt.original, got, tt.want)
+		}
+	}
+}
+
+// TestSavings tests compression savings and their report line
+func TestSavings(t *testing.T) {
+	tests := []struct {
+		compressed, original Bytes
+		wantDelta            Delta
+		wantPercent          float64
+		wantLine             string
+	}{
+		{times(MiB, 2048-786), times(GiB, 2), Delta{times(MiB, 786), false}, 38.37890625, "saved 786.00 MiB (38%)"},
+		{times(MiB, 4), times(MiB, 10), Delta{times(MiB, 6), false}, 60, "saved 6.00 MiB (60%)"},
+		{MB, MB, Delta{}, 0, "saved 0.00 B (0%)"},
+		{Bytes{51_000, 0}, Bytes{50_000, 0}, Delta{KB, true}, -2, "grew 1000.00 B (2%)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantLine, func(t *testing.T) {
+			delta, percent, err := Savings(tt.compressed, tt.original)
+			if err != nil {
+				t.Fatalf("Savings() error = %v", err)
+			}
+			if delta != tt.wantDelta || percent != tt.wantPercent {
+				t.Errorf("Savings() = %+v, %v, want %+v, %v", delta, percent, tt.wantDelta, tt.wantPercent)
+			}
+			line, err := FormatSavings(tt.compressed, tt.original, WithDecimalUnits(false))
+			if err != nil {
+				t.Fatalf("FormatSavings() error = %v", err)
+			}
+			if line != tt.wantLine {
+				t.Errorf("FormatSavings() = %q, want %q", line, tt.wantLine)
+			}
+		})
+	}
+
+	if _, _, err := Savings(None, None); err == nil {
+		t.Error("Savings() of zero bytes returned no error")
+	}
+	if _, err := FormatSavings(None, None); err == nil {
+		t.Error("FormatSavings() of zero bytes returned no error")
+	}
+	if _, err := FormatSavings(None, MB, WithForcedUnit(Bytes{3, 0})); err == nil {
+		t.Error("FormatSavings() with an invalid option returned no error")
+	}
+}
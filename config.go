@@ -0,0 +1,85 @@
+package bytesize
+
+// Config bundles parse and format options so that a service can configure
+// them once, at its composition root, and share the result. A Config is
+// immutable after NewConfig returns and safe for concurrent use.
+type Config struct {
+	formatter    *Formatter
+	parseOptions *parseOptions
+}
+
+// ConfigOption configures a Config.
+type ConfigOption func(*configOptions)
+
+type configOptions struct {
+	format []FormatOption
+	parse  []ParseOption
+}
+
+// WithFormatOptions adds format options to a Config.
+func WithFormatOptions(opts ...FormatOption) ConfigOption {
+	return func(c *configOptions) {
+		c.format = append(c.format, opts...)
+	}
+}
+
+// WithParseOptions adds parse options to a Config.
+func WithParseOptions(opts ...ParseOption) ConfigOption {
+	return func(c *configOptions) {
+		c.parse = append(c.parse, opts...)
+	}
+}
+
+// NewConfig returns a Config with the specified options. The format options
+// are resolved immediately, on top of the Default variables and the options
+// set by SetDefaultFormatOptions, so later changes to those do not affect
+// the Config. It returns an error if any of the options are invalid.
+func NewConfig(opts ...ConfigOption) (*Config, error) {
+	var c configOptions
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	formatter, err := NewFormatter(c.format...)
+	if err != nil {
+		return nil, err
+	}
+	parseOptions, err := newParseOptions(c.parse...)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{formatter: formatter, parseOptions: parseOptions}, nil
+}
+
+// Parse parses s like Parse with the Config's parse options.
+func (c *Config) Parse(s string) (Bytes, error) {
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	b, _, err := parse(s, c.parseOptions, sc, nil)
+	return b, err
+}
+
+// Format formats b with the Config's format options, followed by opts. It
+// returns an error if any of opts are invalid.
+func (c *Config) Format(b Bytes, opts ...FormatOption) (string, error) {
+	if len(opts) == 0 {
+		return c.formatter.Format(b), nil
+	}
+	formatOptions := *c.formatter.opts
+	for _, opt := range opts {
+		if err := opt(&formatOptions); err != nil {
+			return "", err
+		}
+	}
+	return b.formatWith(&formatOptions), nil
+}
+
+// String formats b with the Config's format options.
+func (c *Config) String(b Bytes) string {
+	return c.formatter.Format(b)
+}
+
+// Formatter returns a Formatter using the Config's format options.
+func (c *Config) Formatter() *Formatter {
+	return c.formatter
+}
@@ -0,0 +1,91 @@
+package bytesize
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestConfig tests parsing and formatting with a Config
+func TestConfig(t *testing.T) {
+	cfg, err := NewConfig(
+		WithFormatOptions(WithDecimalUnits(false), WithFormatString("%.1f %s")),
+		WithParseOptions(WithFractionalRounding(RoundCeil), WithKubernetesSuffixParsing()),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	if got, err := cfg.Parse("0.1 B"); err != nil || got != One {
+		t.Errorf("Parse(\"0.1 B\") = %v, %v, want 1", Uint128(got), err)
+	}
+	if got, err := cfg.Parse("2Gi"); err != nil || got != Bytes(Uint128(GiB).Mul64(2)) {
+		t.Errorf("Parse(\"2Gi\") = %v, %v, want 2 GiB", Uint128(got), err)
+	}
+	if got := cfg.String(Bytes{1536, 0}); got != "1.5 KiB" {
+		t.Errorf("String() = %q, want %q", got, "1.5 KiB")
+	}
+	if got, err := cfg.Format(GB); err != nil || got != "953.7 MiB" {
+		t.Errorf("Format(GB) = %q, %v, want %q", got, err, "953.7 MiB")
+	}
+	if got, err := cfg.Format(GB, WithLongUnits(true)); err != nil || got != "953.7 Mebibytes" {
+		t.Errorf("Format(GB, WithLongUnits(true)) = %q, %v, want %q", got, err, "953.7 Mebibytes")
+	}
+	if got := cfg.String(GB); got != "953.7 MiB" {
+		t.Errorf("String(GB) = %q after a Format override, want %q", got, "953.7 MiB")
+	}
+	if _, err := cfg.Format(GB, WithFormatString("")); err == nil {
+		t.Error("Format() with an invalid option returned no error")
+	}
+	if got := cfg.Formatter().Format(KiB); got != "1.0 KiB" {
+		t.Errorf("Formatter().Format() = %q, want %q", got, "1.0 KiB")
+	}
+}
+
+// TestConfigImmutable tests that a Config is unaffected by later changes to
+// the process-wide defaults
+func TestConfigImmutable(t *testing.T) {
+	cfg, err := NewConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer SetDefaultFormatOptions()
+	if err := SetDefaultFormatOptions(WithDecimalUnits(false)); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.String(MB); got != "1.00 MB" {
+		t.Errorf("String(MB) = %q after changing the defaults, want %q", got, "1.00 MB")
+	}
+}
+
+// TestNewConfigErrors tests that NewConfig rejects invalid options
+func TestNewConfigErrors(t *testing.T) {
+	if _, err := NewConfig(WithFormatOptions(WithFormatString(""))); err == nil {
+		t.Error("NewConfig() with an invalid format option returned no error")
+	}
+	if _, err := NewConfig(WithParseOptions(WithFractionalRounding(RoundingMode(99)))); err == nil {
+		t.Error("NewConfig() with an invalid parse option returned no error")
+	}
+}
+
+// TestConfigConcurrent tests that a Config can be shared between goroutines
+func TestConfigConcurrent(t *testing.T) {
+	cfg, err := NewConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				b, err := cfg.Parse("1.5 GB")
+				if err != nil || cfg.String(b) != "1.50 GB" {
+					t.Errorf("Parse and String = %v, %v", cfg.String(b), err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
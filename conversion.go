@@ -0,0 +1,38 @@
+package bytesize
+
+import "math/big"
+
+// Conversion is a size expressed in one unit.
+type Conversion struct {
+	Unit  Unit
+	Value float64
+}
+
+// Conversions returns b expressed in every SI and IEC unit, in a fixed
+// order: the byte, then the SI units and then the IEC units, each in
+// ascending order, so that output rendered from it is the same on every
+// run. Each value is the float64 nearest the exact quotient.
+func Conversions(b Bytes) []Conversion {
+	conversions := make([]Conversion, 0, 1+len(siUnits)+len(iecUnits))
+	n := Uint128(b).Big()
+	for _, units := range [][]Unit{{byteUnit}, siUnits, iecUnits} {
+		for _, u := range units {
+			f, _ := new(big.Rat).SetFrac(n, Uint128(u.Factor).Big()).Float64()
+			conversions = append(conversions, Conversion{Unit: u, Value: f})
+		}
+	}
+	return conversions
+}
+
+// ConversionTable returns the Conversions of b keyed by the short unit
+// name, e.g. "B", "KB" and "KiB", for looking up a unit. Ranging over the
+// map visits the units in random order; range over Conversions instead
+// where the order matters, such as in generated documentation.
+func ConversionTable(b Bytes) map[string]float64 {
+	conversions := Conversions(b)
+	table := make(map[string]float64, len(conversions))
+	for _, c := range conversions {
+		table[c.Unit.Short] = c.Value
+	}
+	return table
+}
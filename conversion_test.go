@@ -0,0 +1,68 @@
+package bytesize
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestConversionTable tests expressing a size in every unit
+func TestConversionTable(t *testing.T) {
+	table := ConversionTable(Bytes{1_572_864, 0})
+
+	if len(table) != 21 {
+		t.Errorf("ConversionTable() has %d entries, want 21", len(table))
+	}
+
+	want := map[string]float64{
+		"B":   1_572_864,
+		"KB":  1_572.864,
+		"MB":  1.572864,
+		"KiB": 1_536,
+		"MiB": 1.5,
+		"GiB": 1.5 / 1024,
+		"QB":  1.572864e-24,
+	}
+	for unit, v := range want {
+		if got := table[unit]; got != v {
+			t.Errorf("ConversionTable()[%q] = %v, want %v", unit, got, v)
+		}
+	}
+}
+
+// TestConversionTableExtremes tests the table for zero and the largest size
+func TestConversionTableExtremes(t *testing.T) {
+	for unit, v := range ConversionTable(None) {
+		if v != 0 {
+			t.Errorf("ConversionTable(0)[%q] = %v, want 0", unit, v)
+		}
+	}
+	if got := ConversionTable(Bytes(Max))["QiB"]; got != 268435456 {
+		t.Errorf("ConversionTable(Max)[\"QiB\"] = %v, want 268435456", got)
+	}
+}
+
+// TestConversions tests that the conversions are in a fixed unit order
+// and agree with ConversionTable
+func TestConversions(t *testing.T) {
+	b := Bytes{1_572_864, 0}
+	conversions := Conversions(b)
+
+	var units []string
+	for _, c := range conversions {
+		units = append(units, c.Unit.Short)
+	}
+	want := []string{
+		"B", "KB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", "RB", "QB",
+		"KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB", "RiB", "QiB",
+	}
+	if !slices.Equal(units, want) {
+		t.Errorf("Conversions() units = %v, want %v", units, want)
+	}
+
+	table := ConversionTable(b)
+	for _, c := range conversions {
+		if table[c.Unit.Short] != c.Value {
+			t.Errorf("ConversionTable()[%q] = %v, Conversions() has %v", c.Unit.Short, table[c.Unit.Short], c.Value)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package bytesize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrTooLarge is returned, wrapped, by Copy when the source holds more
+// than the size set with WithMaxSize.
+var ErrTooLarge = errors.New("size limit exceeded")
+
+// CopyOption configures Copy.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	ctx      context.Context
+	progress func(total Bytes)
+	limiter  *Limiter
+	maxSize  *Bytes
+}
+
+// WithProgress calls fn with the total copied so far after each write.
+// Wrap the destination in a ThresholdWriter instead to report less often.
+func WithProgress(fn func(total Bytes)) CopyOption {
+	return func(c *copyOptions) {
+		c.progress = fn
+	}
+}
+
+// WithRateLimit paces the copy with l, which may be shared with other
+// copies to limit their combined rate.
+func WithRateLimit(l *Limiter) CopyOption {
+	return func(c *copyOptions) {
+		c.limiter = l
+	}
+}
+
+// WithMaxSize limits the copy to max bytes. If the source holds more, Copy
+// copies max bytes and returns an error matching ErrTooLarge.
+func WithMaxSize(max Bytes) CopyOption {
+	return func(c *copyOptions) {
+		c.maxSize = &max
+	}
+}
+
+// WithCopyContext stops the copy, between chunks, when ctx is done.
+func WithCopyContext(ctx context.Context) CopyOption {
+	return func(c *copyOptions) {
+		c.ctx = ctx
+	}
+}
+
+// Copy copies from src to dst until EOF or an error, like io.Copy, and
+// returns the number of bytes copied. Options add progress reporting, rate
+// limiting and a size limit.
+func Copy(dst io.Writer, src io.Reader, opts ...CopyOption) (Bytes, error) {
+	c := copyOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var total Bytes
+	buf := make([]byte, 32<<10)
+	for {
+		if err := c.ctx.Err(); err != nil {
+			return total, err
+		}
+		chunk := buf
+		if c.maxSize != nil {
+			// Read one byte past the limit to tell whether src holds more.
+			// A limit of Max leaves no room for that byte, and nothing can
+			// exceed it anyway.
+			left, err := Uint128(*c.maxSize).SubBytes(total).Add64Err(1)
+			if err == nil && left.Cmp64(uint64(len(chunk))) < 0 {
+				chunk = chunk[:left.Lo]
+			}
+		}
+
+		nr, readErr := src.Read(chunk)
+		if c.maxSize != nil && Uint128(total).Add64(uint64(nr)).CmpBytes(*c.maxSize) > 0 {
+			nr--
+			readErr = fmt.Errorf("%w: more than %s", ErrTooLarge, *c.maxSize)
+		}
+		if nr > 0 {
+			if c.limiter != nil {
+				if err := c.limiter.Wait(c.ctx, Bytes{uint64(nr), 0}); err != nil {
+					return total, err
+				}
+			}
+			nw, writeErr := dst.Write(buf[:nr])
+			total = Bytes(Uint128(total).Add64(uint64(nw)))
+			if c.progress != nil {
+				c.progress(total)
+			}
+			if writeErr != nil {
+				return total, writeErr
+			}
+			if nw != nr {
+				return total, io.ErrShortWrite
+			}
+		}
+		if readErr == io.EOF {
+			return total, nil
+		}
+		if readErr != nil {
+			return total, readErr
+		}
+	}
+}
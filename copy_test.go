@@ -0,0 +1,96 @@
+package bytesize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCopy tests copying with and without a size limit
+func TestCopy(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		opts    []CopyOption
+		want    int
+		wantErr error
+	}{
+		{"empty", 0, nil, 0, nil},
+		{"small", 10, nil, 10, nil},
+		{"several chunks", 100_000, nil, 100_000, nil},
+		{"under limit", 10, []CopyOption{WithMaxSize(Bytes{11, 0})}, 10, nil},
+		{"at limit", 100_000, []CopyOption{WithMaxSize(Bytes{100_000, 0})}, 100_000, nil},
+		{"over limit", 100_001, []CopyOption{WithMaxSize(Bytes{100_000, 0})}, 100_000, ErrTooLarge},
+		{"zero limit", 1, []CopyOption{WithMaxSize(None)}, 0, ErrTooLarge},
+		{"largest limit", 100_000, []CopyOption{WithMaxSize(Bytes(Max))}, 100_000, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := strings.Repeat("x", tt.size)
+			var dst bytes.Buffer
+			got, err := Copy(&dst, strings.NewReader(src), tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Copy() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != (Bytes{uint64(tt.want), 0}) || dst.Len() != tt.want {
+				t.Errorf("Copy() = %v with %d bytes written, want %d", got, dst.Len(), tt.want)
+			}
+		})
+	}
+}
+
+// TestCopyProgress tests the progress callback of Copy
+func TestCopyProgress(t *testing.T) {
+	var totals []Bytes
+	_, err := Copy(io.Discard, strings.NewReader(strings.Repeat("x", 80<<10)), WithProgress(func(total Bytes) {
+		totals = append(totals, total)
+	}))
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	want := []Bytes{{32 << 10, 0}, {64 << 10, 0}, {80 << 10, 0}}
+	if len(totals) != len(want) {
+		t.Fatalf("progress = %v, want %v", totals, want)
+	}
+	for i := range want {
+		if totals[i] != want[i] {
+			t.Errorf("progress[%d] = %v, want %v", i, totals[i], want[i])
+		}
+	}
+}
+
+// TestCopyRateLimit tests that Copy is paced by a Limiter
+func TestCopyRateLimit(t *testing.T) {
+	l := NewLimiter(Rate{Bytes{32 << 10, 0}, 50 * time.Millisecond})
+	start := time.Now()
+	n, err := Copy(io.Discard, strings.NewReader(strings.Repeat("x", 96<<10)), WithRateLimit(l))
+	if err != nil || n != (Bytes{96 << 10, 0}) {
+		t.Fatalf("Copy() = %v, %v", n, err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Copy() of 96 KiB at 32 KiB/50ms took %v, want at least 100ms", elapsed)
+	}
+}
+
+// TestCopyContext tests that Copy stops when its context is done
+func TestCopyContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err := Copy(io.Discard, strings.NewReader("data"), WithCopyContext(ctx))
+	if !errors.Is(err, context.Canceled) || !Uint128(n).IsZero() {
+		t.Errorf("Copy() = %v, %v, want 0 and context.Canceled", n, err)
+	}
+}
+
+// TestCopyWriteError tests that Copy reports write errors
+func TestCopyWriteError(t *testing.T) {
+	n, err := Copy(&shortWriter{limit: 3}, strings.NewReader("hello"))
+	if err == nil || n != (Bytes{3, 0}) {
+		t.Errorf("Copy() = %v, %v, want 3 bytes and an error", n, err)
+	}
+}
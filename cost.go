@@ -0,0 +1,91 @@
+package bytesize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Cost returns the price of b at pricePerUnit for every unit, such as
+// 0.09 per GB, prorated for a partial unit: 1.5 GB at 0.09 per GB is 0.135.
+// The ratio of b to unit is exact before it is rounded to a float64. It
+// returns an error if unit is zero or pricePerUnit is negative, NaN or
+// infinite.
+func Cost(b Bytes, pricePerUnit float64, unit Bytes) (float64, error) {
+	if err := checkPrice(pricePerUnit); err != nil {
+		return 0, err
+	}
+	if Uint128(unit).IsZero() {
+		return 0, fmt.Errorf("invalid unit: zero")
+	}
+	units, _ := new(big.Rat).SetFrac(Uint128(b).Big(), Uint128(unit).Big()).Float64()
+	return units * pricePerUnit, nil
+}
+
+// checkPrice returns an error if price is negative, NaN or infinite.
+func checkPrice(price float64) error {
+	if math.IsNaN(price) || math.IsInf(price, 0) || price < 0 {
+		return fmt.Errorf("invalid price: %v", price)
+	}
+	return nil
+}
+
+// Tier is one tier of a Tiered price: the next Size bytes cost
+// PricePerUnit for every unit of the Tiered. A Size of zero means every
+// remaining byte, and is only allowed in the last tier.
+type Tier struct {
+	Size         Bytes
+	PricePerUnit float64
+}
+
+// Tiered prices sizes in tiers, as cloud providers bill storage and
+// egress: for example the first 50 GB free, the next 950 GB at 0.09 per GB
+// and the rest at 0.085 per GB.
+type Tiered struct {
+	unit  Bytes
+	tiers []Tier
+}
+
+// NewTiered returns a Tiered pricing sizes in tiers, in order, with prices
+// per unit. It returns an error if unit is zero, if there are no tiers, if
+// a price is invalid, or if a tier other than the last has a zero Size.
+func NewTiered(unit Bytes, tiers ...Tier) (*Tiered, error) {
+	if Uint128(unit).IsZero() {
+		return nil, fmt.Errorf("invalid unit: zero")
+	}
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("no tiers")
+	}
+	for i, tier := range tiers {
+		if err := checkPrice(tier.PricePerUnit); err != nil {
+			return nil, fmt.Errorf("tier %d: %w", i, err)
+		}
+		if Uint128(tier.Size).IsZero() && i != len(tiers)-1 {
+			return nil, fmt.Errorf("tier %d: only the last tier may have no size", i)
+		}
+	}
+	return &Tiered{unit: unit, tiers: append([]Tier(nil), tiers...)}, nil
+}
+
+// Cost returns the price of b, filling each tier in turn. It returns an
+// error if b is larger than the tiers in total.
+func (t *Tiered) Cost(b Bytes) (float64, error) {
+	total := 0.0
+	remaining := Uint128(b)
+	for _, tier := range t.tiers {
+		if remaining.IsZero() {
+			break
+		}
+		portion := remaining
+		if !Uint128(tier.Size).IsZero() && remaining.CmpBytes(tier.Size) > 0 {
+			portion = Uint128(tier.Size)
+		}
+		cost, _ := Cost(Bytes(portion), tier.PricePerUnit, t.unit)
+		total += cost
+		remaining = remaining.Sub(portion)
+	}
+	if !remaining.IsZero() {
+		return 0, fmt.Errorf("%s exceeds the tiers by %s", b, Bytes(remaining))
+	}
+	return total, nil
+}
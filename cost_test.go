@@ -0,0 +1,114 @@
+package bytesize
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCost tests pricing sizes per unit
+func TestCost(t *testing.T) {
+	tests := []struct {
+		b     Bytes
+		price float64
+		unit  Bytes
+		want  float64
+	}{
+		{GB, 0.09, GB, 0.09},
+		{Bytes{1_500_000_000, 0}, 0.09, GB, 0.135},
+		{times(GiB, 10), 0.1, GiB, 1},
+		{TB, 23, TB, 23},
+		{None, 0.09, GB, 0},
+		{TB, 0, GB, 0},
+		{Bytes(Max), 1, QB, 340282366.9209385},
+	}
+
+	for _, tt := range tests {
+		got, err := Cost(tt.b, tt.price, tt.unit)
+		if err != nil {
+			t.Fatalf("Cost(%v, %v, %v) error = %v", Uint128(tt.b), tt.price, Uint128(tt.unit), err)
+		}
+		if math.Abs(got-tt.want) > 1e-9*math.Max(1, tt.want) {
+			t.Errorf("Cost(%v, %v, %v) = %v, want %v", Uint128(tt.b), tt.price, Uint128(tt.unit), got, tt.want)
+		}
+	}
+
+	for _, price := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := Cost(GB, price, GB); err == nil {
+			t.Errorf("Cost() with price %v should have errored", price)
+		}
+	}
+	if _, err := Cost(GB, 1, None); err == nil {
+		t.Errorf("Cost() with a zero unit should have errored")
+	}
+}
+
+// TestTieredCost tests pricing sizes in tiers
+func TestTieredCost(t *testing.T) {
+	tiered, err := NewTiered(GB,
+		Tier{Size: times(GB, 50), PricePerUnit: 0},
+		Tier{Size: times(GB, 950), PricePerUnit: 0.09},
+		Tier{PricePerUnit: 0.085},
+	)
+	if err != nil {
+		t.Fatalf("NewTiered() error = %v", err)
+	}
+
+	tests := []struct {
+		b    Bytes
+		want float64
+	}{
+		{None, 0},
+		{times(GB, 50), 0},
+		{times(GB, 51), 0.09},
+		{TB, 85.5},
+		{times(TB, 2), 85.5 + 85},
+	}
+
+	for _, tt := range tests {
+		got, err := tiered.Cost(tt.b)
+		if err != nil {
+			t.Fatalf("Cost(%s) error = %v", tt.b, err)
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Cost(%s) = %v, want %v", tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestTieredCostExceeded tests that sizes beyond bounded tiers are
+// rejected
+func TestTieredCostExceeded(t *testing.T) {
+	tiered, err := NewTiered(GB, Tier{Size: times(GB, 5), PricePerUnit: 1})
+	if err != nil {
+		t.Fatalf("NewTiered() error = %v", err)
+	}
+	if got, err := tiered.Cost(times(GB, 5)); err != nil || got != 5 {
+		t.Errorf("Cost(5 GB) = %v, %v, want 5, nil", got, err)
+	}
+	if _, err := tiered.Cost(times(GB, 6)); err == nil {
+		t.Errorf("Cost(6 GB) should have errored")
+	}
+}
+
+// TestNewTieredErrors tests that invalid tiers are rejected
+func TestNewTieredErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		unit  Bytes
+		tiers []Tier
+	}{
+		{"zero unit", None, []Tier{{PricePerUnit: 1}}},
+		{"no tiers", GB, nil},
+		{"negative price", GB, []Tier{{PricePerUnit: -1}}},
+		{"NaN price", GB, []Tier{{PricePerUnit: math.NaN()}}},
+		{"unbounded tier first", GB, []Tier{{PricePerUnit: 1}, {Size: GB, PricePerUnit: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTiered(tt.unit, tt.tiers...); err == nil {
+				t.Errorf("NewTiered() should have errored")
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package bytesize
+
+import "sync"
+
+// Counter is a running total of bytes, such as the bytes a service has
+// sent, that is safe for concurrent use. The zero value is an empty
+// Counter.
+type Counter struct {
+	mu    sync.Mutex
+	total Bytes
+}
+
+// Add adds n to the total. The total wraps around if it overflows, which
+// takes more than 10^38 bytes.
+func (c *Counter) Add(n Bytes) {
+	c.mu.Lock()
+	c.total = Bytes(Uint128(c.total).AddWrapBytes(n))
+	c.mu.Unlock()
+}
+
+// Total returns the total so far.
+func (c *Counter) Total() Bytes {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// Reset sets the total to zero and returns what it was.
+func (c *Counter) Reset() Bytes {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	total := c.total
+	c.total = Bytes{}
+	return total
+}
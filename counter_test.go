@@ -0,0 +1,34 @@
+package bytesize
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestCounter tests adding to, reading and resetting a Counter
+func TestCounter(t *testing.T) {
+	var c Counter
+	if got := c.Total(); got != None {
+		t.Errorf("Total() = %s, want 0 B", got)
+	}
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Add(KB)
+		}()
+	}
+	wg.Wait()
+	if got := c.Total(); got != (Bytes{100_000, 0}) {
+		t.Errorf("Total() = %v, want 100000", Uint128(got))
+	}
+
+	if got := c.Reset(); got != (Bytes{100_000, 0}) {
+		t.Errorf("Reset() = %v, want 100000", Uint128(got))
+	}
+	if got := c.Total(); got != None {
+		t.Errorf("Total() after Reset() = %s, want 0 B", got)
+	}
+}
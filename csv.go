@@ -0,0 +1,56 @@
+package bytesize
+
+import "fmt"
+
+// ParseColumn parses column col of each record like Parse, for records
+// read with encoding/csv. Skip a header row by passing records[1:]. It
+// stops at the first record that is too short or does not parse, and
+// returns an error naming its row, counted from zero.
+func ParseColumn(records [][]string, col int, opts ...ParseOption) ([]Bytes, error) {
+	if col < 0 {
+		return nil, fmt.Errorf("invalid column %d", col)
+	}
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]Bytes, len(records))
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	for i, record := range records {
+		if col >= len(record) {
+			return nil, fmt.Errorf("row %d: no column %d in %d fields", i, col, len(record))
+		}
+		if values[i], _, err = parse(record[col], parseOptions, sc, nil); err != nil {
+			return nil, fmt.Errorf("row %d, column %d: %w", i, col, err)
+		}
+	}
+	return values, nil
+}
+
+// FormatColumn is the inverse of ParseColumn: it sets column col of each
+// record to the matching value of values formatted like Format, for
+// writing with encoding/csv. Records too short for col are extended with
+// empty fields. It returns an error if the lengths of records and values
+// differ or any of the options are invalid, without changing records.
+func FormatColumn(records [][]string, col int, values []Bytes, opts ...FormatOption) error {
+	if col < 0 {
+		return fmt.Errorf("invalid column %d", col)
+	}
+	if len(records) != len(values) {
+		return fmt.Errorf("%d records but %d values", len(records), len(values))
+	}
+	formatted, err := FormatBatch(values, opts...)
+	if err != nil {
+		return err
+	}
+
+	for i, s := range formatted {
+		for len(records[i]) <= col {
+			records[i] = append(records[i], "")
+		}
+		records[i][col] = s
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package bytesize
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseColumn tests parsing a column of CSV records
+func TestParseColumn(t *testing.T) {
+	input := "name,size\nlogs,1.5 GB\ncache,512 MiB\nempty,0 B\n"
+	records, err := csv.NewReader(strings.NewReader(input)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseColumn(records[1:], 1)
+	if err != nil {
+		t.Fatalf("ParseColumn() error = %v", err)
+	}
+	want := []Bytes{times(MB, 1500), times(MiB, 512), None}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseColumn() = %v, want %v", got, want)
+	}
+}
+
+// TestParseColumnErrors tests the errors of ParseColumn
+func TestParseColumnErrors(t *testing.T) {
+	records := [][]string{{"a", "1 KB"}, {"b"}, {"c", "lots"}}
+	tests := []struct {
+		name    string
+		records [][]string
+		col     int
+		opts    []ParseOption
+		wantErr string
+	}{
+		{"short record", records, 1, nil, "row 1: no column 1 in 1 fields"},
+		{"invalid value", records[2:], 1, nil, "row 0, column 1: unknown unit"},
+		{"negative column", records, -1, nil, "invalid column -1"},
+		{"invalid option", records, 1, []ParseOption{WithFractionalRounding(RoundingMode(-1))}, "rounding"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseColumn(tt.records, tt.col, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseColumn() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestFormatColumn tests formatting values into a column of CSV records
+func TestFormatColumn(t *testing.T) {
+	records := [][]string{{"logs", "x"}, {"cache"}}
+	if err := FormatColumn(records, 2, []Bytes{times(MB, 1500), times(MiB, 512)}); err != nil {
+		t.Fatalf("FormatColumn() error = %v", err)
+	}
+	want := [][]string{{"logs", "x", "1.50 GB"}, {"cache", "", "536.87 MB"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q, want %q", records, want)
+	}
+
+	// The formatted column parses back to the values
+	got, err := ParseColumn(records, 2)
+	if err != nil {
+		t.Fatalf("ParseColumn() error = %v", err)
+	}
+	if want := []Bytes{times(MB, 1500), Bytes{536_870_000, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseColumn() = %v, want %v", got, want)
+	}
+
+	if err := FormatColumn(records, 0, []Bytes{KB}); err == nil {
+		t.Error("FormatColumn() with mismatched lengths returned no error")
+	}
+	if err := FormatColumn(records, -1, []Bytes{KB, KB}); err == nil {
+		t.Error("FormatColumn() with a negative column returned no error")
+	}
+	if err := FormatColumn(records, 0, []Bytes{KB, KB}, WithForcedUnit(Bytes{3, 0})); err == nil {
+		t.Error("FormatColumn() with an invalid option returned no error")
+	}
+}
@@ -0,0 +1,226 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// quotient is the exact value n/d. It implements fmt.Formatter so that
+// Format can print a size in a unit without going through math/big: the
+// 'f' and 'F' verbs are produced digit by digit from Uint128.QuoRem and
+// rounded half-to-even on the exact value. Other verbs fall back to a
+// big.Float.
+type quotient struct {
+	n, d Uint128
+
+	// shift scales n by 2^shift, for numerators that overflow 128 bits,
+	// such as the bit count of a huge size. A quotient with a shift is
+	// always formatted with math/big.
+	shift uint
+}
+
+// isOne reports whether the quotient is exactly 1. A shifted numerator is
+// more than any Uint128, so it is never one.
+func (v quotient) isOne() bool {
+	return v.shift == 0 && v.n == v.d
+}
+
+// bigNum returns the numerator, including any shift, as a big.Int.
+func (v quotient) bigNum() *big.Int {
+	n := v.n.Big()
+	return n.Lsh(n, v.shift)
+}
+
+// Format implements fmt.Formatter.
+func (v quotient) Format(f fmt.State, verb rune) {
+	prec, hasPrec := f.Precision()
+	if !hasPrec {
+		prec = 6
+	}
+	if (verb != 'f' && verb != 'F') || v.d.IsZero() || v.shift != 0 {
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.bigFloat())
+		return
+	}
+	digits, ok := v.fixed(prec)
+	if !ok {
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.bigFloat())
+		return
+	}
+	writePadded(f, digits)
+}
+
+// fixed returns the quotient in fixed-point notation with prec digits after
+// the decimal point, rounded half-to-even. It returns false if a digit
+// cannot be computed without overflowing 128 bits.
+func (v quotient) fixed(prec int) (string, bool) {
+	return v.fixedRounded(prec, RoundHalfEven)
+}
+
+// fixedRounded is like fixed, but rounds under mode.
+func (v quotient) fixedRounded(prec int, mode RoundingMode) (string, bool) {
+	if v.shift != 0 {
+		return "", false
+	}
+	q, r := v.n.QuoRem(v.d)
+	frac := make([]byte, prec)
+	for i := range frac {
+		r10, err := r.Mul64Err(10)
+		if err != nil {
+			return "", false
+		}
+		var digit Uint128
+		digit, r = r10.QuoRem(v.d)
+		frac[i] = '0' + byte(digit.Lo)
+	}
+
+	// Round on what is left of the exact remainder
+	r2, err := r.Mul64Err(2)
+	if err != nil {
+		return "", false
+	}
+	last := q.Lo
+	if prec > 0 {
+		last = uint64(frac[prec-1] - '0')
+	}
+	if !r.IsZero() && mode.roundUp(r2.Cmp(v.d), last%2 == 1) {
+		i := prec - 1
+		for ; i >= 0 && frac[i] == '9'; i-- {
+			frac[i] = '0'
+		}
+		if i >= 0 {
+			frac[i]++
+		} else {
+			q = q.AddWrap64(1)
+		}
+	}
+
+	if prec == 0 {
+		return q.String(), true
+	}
+	return q.String() + "." + string(frac), true
+}
+
+// bigFloat returns the quotient as a big.Float, as Format computed it
+// before quotient existed.
+func (v quotient) bigFloat() *big.Float {
+	n := new(big.Float).SetInt(v.bigNum())
+	d := new(big.Float).SetInt(v.d.Big())
+	return new(big.Float).Quo(n, d)
+}
+
+// writePadded writes the non-negative number s to f, honoring the width
+// and the '+', ' ', '-' and '0' flags.
+func writePadded(f fmt.State, s string) {
+	sign := ""
+	switch {
+	case f.Flag('+'):
+		sign = "+"
+	case f.Flag(' '):
+		sign = " "
+	}
+
+	width, _ := f.Width()
+	pad := width - len(sign) - len(s)
+	switch {
+	case pad <= 0:
+		fmt.Fprint(f, sign, s)
+	case f.Flag('-'):
+		fmt.Fprint(f, sign, s, strings.Repeat(" ", pad))
+	case f.Flag('0'):
+		fmt.Fprint(f, sign, strings.Repeat("0", pad), s)
+	default:
+		fmt.Fprint(f, strings.Repeat(" ", pad), sign, s)
+	}
+}
+
+// roundedQuotient is a quotient that the 'f' and 'F' verbs print rounded
+// under mode rather than half-to-even. If digits is positive, it prints with
+// digits significant digits rather than with the precision of the verb.
+// Digits of the whole part are never rounded away, so 12345 prints as
+// "12345" for any number of digits. Zero prints as "0".
+type roundedQuotient struct {
+	quotient
+	digits int
+	mode   RoundingMode
+}
+
+// Format implements fmt.Formatter.
+func (v roundedQuotient) Format(f fmt.State, verb rune) {
+	if (verb != 'f' && verb != 'F') || v.d.IsZero() {
+		v.quotient.Format(f, verb)
+		return
+	}
+	prec, hasPrec := f.Precision()
+	if !hasPrec {
+		prec = 6
+	}
+	if v.digits > 0 {
+		prec = v.precision()
+	}
+	digits, ok := v.fixedRounded(prec, v.mode)
+	if ok && v.digits > 0 && prec > 0 && countSignificant(digits) > v.digits {
+		// Rounding carried into a new leading digit, as in 9.996 to 10.00
+		prec--
+		digits, ok = v.fixedRounded(prec, v.mode)
+	}
+	if !ok {
+		digits = v.bigFixed(prec)
+	}
+	writePadded(f, digits)
+}
+
+// bigFixed is like fixedRounded, computed with math/big for quotients whose
+// digits overflow 128 bits.
+func (v roundedQuotient) bigFixed(prec int) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(v.bigNum(), scale), v.d.Big())
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() != 0 && v.mode.roundsUp(quo, rem, r.Denom(), new(big.Int)) {
+		quo.Add(quo, bigOne)
+	}
+	s := quo.String()
+	if prec == 0 {
+		return s
+	}
+	if len(s) <= prec {
+		s = strings.Repeat("0", prec-len(s)+1) + s
+	}
+	return s[:len(s)-prec] + "." + s[len(s)-prec:]
+}
+
+// precision returns the number of digits after the decimal point that
+// leaves v.digits significant digits, before any carry from rounding.
+func (v roundedQuotient) precision() int {
+	if v.shift != 0 {
+		// The quotient is at least one
+		q := new(big.Int).Quo(v.bigNum(), v.d.Big())
+		return max(0, v.digits-len(q.String()))
+	}
+	q, r := v.n.QuoRem(v.d)
+	if !q.IsZero() {
+		return max(0, v.digits-len(q.String()))
+	}
+	if r.IsZero() {
+		return 0
+	}
+
+	// Count the zeros between the decimal point and the first digit
+	zeros := 0
+	for {
+		r10, err := r.Mul64Err(10)
+		if err != nil || r10.Cmp(v.d) >= 0 {
+			break
+		}
+		r = r10
+		zeros++
+	}
+	return zeros + v.digits
+}
+
+// countSignificant returns the number of significant digits in the fixed
+// point number s.
+func countSignificant(s string) int {
+	s = strings.TrimLeft(strings.Replace(s, ".", "", 1), "0")
+	return len(s)
+}
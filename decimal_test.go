@@ -0,0 +1,128 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestQuotientFormat tests exact fixed-point formatting of quotients
+func TestQuotientFormat(t *testing.T) {
+	tests := []struct {
+		n, d   Uint128
+		format string
+		want   string
+	}{
+		{From64(1234567890), From64(1 << 20), "%.2f", "1177.38"},
+		{From64(1500), From64(1000), "%.2f", "1.50"},
+		{From64(1500), From64(1000), "%.0f", "2"},
+		{From64(2500), From64(1000), "%.0f", "2"},
+		{From64(1005), From64(1000), "%.2f", "1.00"},
+		{From64(1015), From64(1000), "%.2f", "1.02"},
+		{From64(10051), From64(10000), "%.2f", "1.01"},
+		{From64(9995), From64(1000), "%.2f", "10.00"},
+		{From64(999999), From64(1000), "%.1f", "1000.0"},
+		{From64(1), From64(3), "%f", "0.333333"},
+		{From64(2), From64(3), "%.3F", "0.667"},
+		{From64(5), From64(2), "%8.2f", "    2.50"},
+		{From64(5), From64(2), "%-8.2f|", "2.50    |"},
+		{From64(5), From64(2), "%08.2f", "00002.50"},
+		{From64(5), From64(2), "%+.1f", "+2.5"},
+		{From64(5), From64(2), "% .1f", " 2.5"},
+		{From64(5), From64(2), "%v", "2.5"},
+		{From64(5), From64(2), "%g", "2.5"},
+		{From64(5), From64(2), "%.2e", "2.50e+00"},
+		{Max, From64(1), "%.1f", "340282366920938463463374607431768211455.0"},
+		{Uint128(QiB), Uint128(QB), "%.2f", "1.27"},
+		{From64(7), Zero, "%.1f", "+Inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v %s", tt.n, tt.d, tt.format), func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, quotient{n: tt.n, d: tt.d}); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestQuotientMatchesBigRat tests that, away from exact ties, the exact
+// formatting agrees with big.Rat's FloatString, which rounds halves away
+// from zero rather than to even
+func TestQuotientMatchesBigRat(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	units := []Bytes{B, KB, KiB, MB, MiB, GB, GiB, TB, TiB, EB, EiB, ZB, ZiB, QB, QiB}
+
+	for range 10000 {
+		n := Uint128{rng.Uint64(), rng.Uint64() >> rng.IntN(65)}
+		d := Uint128(units[rng.IntN(len(units))])
+		exact := new(big.Rat).SetFrac(n.Big(), d.Big())
+		for _, prec := range []int{0, 1, 2, 3} {
+			format := fmt.Sprintf("%%.%df", prec)
+
+			// Skip exact ties
+			scaled := new(big.Rat).Mul(exact, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)))
+			frac := new(big.Rat).Sub(scaled, new(big.Rat).SetInt(new(big.Int).Quo(scaled.Num(), scaled.Denom())))
+			if frac.Cmp(big.NewRat(1, 2)) == 0 {
+				continue
+			}
+
+			if got, want := fmt.Sprintf(format, quotient{n: n, d: d}), exact.FloatString(prec); got != want {
+				t.Fatalf("Sprintf(%q, %v/%v) = %q, big.Rat gives %q", format, n, d, got, want)
+			}
+		}
+	}
+}
+
+// BenchmarkQuotientFormat benchmarks exact formatting of a quotient
+func BenchmarkQuotientFormat(b *testing.B) {
+	v := quotient{n: From64(1234567890), d: Uint128(MiB)}
+	for b.Loop() {
+		_ = fmt.Sprintf("%.2f", v)
+	}
+}
+
+// BenchmarkQuotientFormatBigFloat benchmarks the big.Float formatting that
+// quotient replaced
+func BenchmarkQuotientFormatBigFloat(b *testing.B) {
+	v := quotient{n: From64(1234567890), d: Uint128(MiB)}
+	for b.Loop() {
+		_ = fmt.Sprintf("%.2f", v.bigFloat())
+	}
+}
+
+// TestRoundedQuotientFormat tests formatting quotients with significant digits
+func TestRoundedQuotientFormat(t *testing.T) {
+	tests := []struct {
+		n, d   Uint128
+		digits int
+		format string
+		want   string
+	}{
+		{From64(999), From64(1), 3, "%.2f", "999"},
+		{From64(1024), From64(1000), 3, "%.2f", "1.02"},
+		{From64(1024), From64(1000), 2, "%.2f", "1.0"},
+		{From64(12345), From64(1000), 3, "%f", "12.3"},
+		{From64(12345), From64(1), 3, "%.2f", "12345"},
+		{From64(9996), From64(1000), 3, "%.2f", "10.0"},
+		{From64(9996), From64(1000), 1, "%.2f", "10"},
+		{From64(123), From64(100000), 2, "%.2f", "0.0012"},
+		{From64(999), From64(10000), 2, "%.2f", "0.10"},
+		{From64(1), From64(2), 1, "%.2f", "0.5"},
+		{Zero, From64(1000), 3, "%.2f", "0"},
+		{From64(1500), From64(1000), 3, "%8f|", "    1.50|"},
+		{From64(1500), From64(1000), 3, "%.1e", "1.5e+00"},
+		{Max, From64(1), 3, "%.2f", "340282366920938463463374607431768211455"},
+		{Max, Max.Sub64(1), 3, "%.2f", "1.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.want, func(t *testing.T) {
+			v := roundedQuotient{quotient{n: tt.n, d: tt.d}, tt.digits, RoundHalfEven}
+			if got := fmt.Sprintf(tt.format, v); got != tt.want {
+				t.Errorf("Sprintf(%q, %v/%v to %d digits) = %q, want %q", tt.format, tt.n, tt.d, tt.digits, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package bytesize
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Decoder reads byte sizes from a stream, one value at a time, so that
+// interactive CLIs and protocol readers can consume sizes without buffering
+// whole lines. Values may be separated by any whitespace or punctuation that
+// cannot be part of a size.
+type Decoder struct {
+	r    io.RuneScanner
+	opts []ParseOption
+
+	// scratch is reused by every Decode call
+	scratch ratScratch
+}
+
+// NewDecoder returns a Decoder reading from r and parsing values with the
+// given options. If r is not an io.RuneScanner, it is wrapped in a
+// bufio.Reader, which may read past the last value decoded.
+func NewDecoder(r io.Reader, opts ...ParseOption) *Decoder {
+	rs, ok := r.(io.RuneScanner)
+	if !ok {
+		rs = bufio.NewReader(r)
+	}
+	return &Decoder{r: rs, opts: opts}
+}
+
+// Decode reads the next value from the stream. It stops at the first rune
+// that cannot continue the value, leaving that rune unread. It returns
+// io.EOF if the stream ends before a value starts, and io.ErrUnexpectedEOF
+// if it ends inside a number that has no unit.
+func (d *Decoder) Decode() (Bytes, error) {
+	token, err := scanSizeToken(d.r)
+	if err != nil {
+		return Bytes{}, err
+	}
+	opts, err := newParseOptions(d.opts...)
+	if err != nil {
+		return Bytes{}, err
+	}
+	b, _, err := parse(token, opts, &d.scratch, nil)
+	return b, err
+}
+
+// ReadBytesValue reads a single value from r. If r is an io.RuneScanner,
+// nothing past the value is consumed; otherwise r is read one byte at a
+// time, and the rune immediately following the value is consumed.
+func ReadBytesValue(r io.Reader, opts ...ParseOption) (Bytes, error) {
+	rs, ok := r.(io.RuneScanner)
+	if !ok {
+		rs = &runeReader{r: r}
+	}
+	d := &Decoder{r: rs, opts: opts}
+	return d.Decode()
+}
+
+// scanSizeToken reads one "<number> <unit>" token from rs, skipping leading
+// whitespace and separators. If the next rune can start neither a token nor
+// a separator, it is consumed and reported as an error, so that a caller
+// decoding in a loop still makes progress.
+func scanSizeToken(rs io.RuneScanner) (string, error) {
+	var sb strings.Builder
+
+	if err := skipSeparators(rs); err != nil {
+		return "", err
+	}
+
+	// Number, then optional whitespace, then unit
+	if err := scanWhile(rs, &sb, isNumberRune); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if sb.Len() > 0 {
+		if err := skipSpace(rs); err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		sb.WriteByte(' ')
+	}
+	if err := scanWhile(rs, &sb, unicode.IsLetter); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if sb.Len() == 0 {
+		r, _, err := rs.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("unexpected %q", r)
+	}
+	return sb.String(), nil
+}
+
+// skipSeparators reads runes from rs up to the first one that is neither
+// whitespace nor punctuation that cannot be part of a size, such as a comma
+// or semicolon, leaving that rune unread.
+func skipSeparators(rs io.RuneScanner) error {
+	for {
+		r, _, err := rs.ReadRune()
+		if err != nil {
+			return err
+		}
+		if !unicode.IsSpace(r) && (!unicode.IsPunct(r) || isNumberRune(r)) {
+			return rs.UnreadRune()
+		}
+	}
+}
+
+// skipSpace reads runes from rs up to the first one that is not whitespace,
+// leaving that rune unread.
+func skipSpace(rs io.RuneScanner) error {
+	for {
+		r, _, err := rs.ReadRune()
+		if err != nil {
+			return err
+		}
+		if !unicode.IsSpace(r) {
+			return rs.UnreadRune()
+		}
+	}
+}
+
+// scanWhile copies runes from rs to sb while accept returns true, unreading
+// the first rejected rune.
+func scanWhile(rs io.RuneScanner, sb *strings.Builder, accept func(rune) bool) error {
+	for {
+		r, _, err := rs.ReadRune()
+		if err != nil {
+			return err
+		}
+		if !accept(r) {
+			return rs.UnreadRune()
+		}
+		sb.WriteRune(r)
+	}
+}
+
+// isNumberRune reports whether r can be part of the numeric part of a size.
+func isNumberRune(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '+'
+}
+
+// runeReader is an io.RuneScanner that reads from r one byte at a time, so
+// that it never consumes more than it returns, plus one rune of pushback.
+type runeReader struct {
+	r       io.Reader
+	last    rune
+	size    int
+	unread  bool
+	scratch [utf8.UTFMax]byte
+}
+
+func (rr *runeReader) ReadRune() (rune, int, error) {
+	if rr.unread {
+		rr.unread = false
+		return rr.last, rr.size, nil
+	}
+	n := 0
+	for n < utf8.UTFMax {
+		if _, err := io.ReadFull(rr.r, rr.scratch[n:n+1]); err != nil {
+			if n > 0 && errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, 0, err
+		}
+		n++
+		if utf8.FullRune(rr.scratch[:n]) {
+			break
+		}
+	}
+	rr.last, rr.size = utf8.DecodeRune(rr.scratch[:n])
+	return rr.last, rr.size, nil
+}
+
+func (rr *runeReader) UnreadRune() error {
+	if rr.unread || rr.size == 0 {
+		return errors.New("bytesize: invalid use of UnreadRune")
+	}
+	rr.unread = true
+	return nil
+}
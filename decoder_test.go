@@ -0,0 +1,135 @@
+package bytesize
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestDecoder tests decoding a stream of values one at a time
+func TestDecoder(t *testing.T) {
+	d := NewDecoder(strings.NewReader("10 MB\n5KiB, 1.5 gigabytes\t2 B; 3 KB,,"))
+	want := []Bytes{
+		Bytes(Uint128(MB).Mul64(10)),
+		Bytes(Uint128(KiB).Mul64(5)),
+		Bytes{1_500_000_000, 0},
+		Bytes{2, 0},
+		Bytes{3000, 0},
+	}
+
+	for i, w := range want {
+		got, err := d.Decode()
+		if err != nil {
+			t.Fatalf("Decode() #%d error = %v", i, err)
+		}
+		if got != w {
+			t.Errorf("Decode() #%d = %v, want %v", i, Uint128(got), Uint128(w))
+		}
+	}
+
+	if _, err := d.Decode(); !errors.Is(err, io.EOF) {
+		t.Errorf("Decode() at end error = %v, want io.EOF", err)
+	}
+}
+
+// TestDecoderProgress tests that a Decoder consumes input it cannot decode,
+// so that decoding in a loop reaches the end of the stream
+func TestDecoderProgress(t *testing.T) {
+	d := NewDecoder(strings.NewReader("10 MB $ 5 KB"))
+	var got []Bytes
+	var errs int
+	for range 10 {
+		b, err := d.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs++
+			continue
+		}
+		got = append(got, b)
+	}
+
+	want := []Bytes{Bytes(Uint128(MB).Mul64(10)), Bytes{5000, 0}}
+	if !slices.Equal(got, want) || errs != 1 {
+		t.Errorf("Decode() loop = %v with %d errors, want %v with 1 error", got, errs, want)
+	}
+}
+
+// TestDecoderErrors tests decoding invalid and truncated input
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", io.EOF},
+		{"   ", io.EOF},
+		{"10", io.ErrUnexpectedEOF},
+		{"10  ", io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewDecoder(strings.NewReader(tt.input)).Decode(); !errors.Is(err, tt.want) {
+			t.Errorf("Decode(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+
+	if _, err := NewDecoder(strings.NewReader("10 XB")).Decode(); err == nil {
+		t.Errorf("Decode(%q) should have errored", "10 XB")
+	}
+}
+
+// TestReadBytesValue tests that ReadBytesValue stops right after the value
+func TestReadBytesValue(t *testing.T) {
+	// strings.Reader is an io.RuneScanner, so nothing past the value is read
+	r := strings.NewReader("  64 MiB;rest")
+	got, err := ReadBytesValue(r)
+	if err != nil {
+		t.Fatalf("ReadBytesValue() error = %v", err)
+	}
+	if got != Bytes(Uint128(MiB).Mul64(64)) {
+		t.Errorf("ReadBytesValue() = %v, want 64 MiB", Uint128(got))
+	}
+	if rest, _ := io.ReadAll(r); string(rest) != ";rest" {
+		t.Errorf("remaining input = %q, want %q", rest, ";rest")
+	}
+
+	// A plain io.Reader loses only the rune following the value
+	pr := struct{ io.Reader }{strings.NewReader("3 kB;rest")}
+	got, err = ReadBytesValue(pr, WithFractionalRounding(RoundCeil))
+	if err != nil {
+		t.Fatalf("ReadBytesValue() error = %v", err)
+	}
+	if got != (Bytes{3000, 0}) {
+		t.Errorf("ReadBytesValue() = %v, want 3000", Uint128(got))
+	}
+	if rest, _ := io.ReadAll(pr); string(rest) != "rest" {
+		t.Errorf("remaining input = %q, want %q", rest, "rest")
+	}
+}
+
+// TestRuneReader tests the unbuffered rune reader with multi-byte runes
+func TestRuneReader(t *testing.T) {
+	rr := &runeReader{r: strings.NewReader("é1")}
+	if err := rr.UnreadRune(); err == nil {
+		t.Errorf("UnreadRune() before ReadRune should error")
+	}
+	r, size, err := rr.ReadRune()
+	if r != 'é' || size != 2 || err != nil {
+		t.Fatalf("ReadRune() = (%q, %d, %v), want ('é', 2, nil)", r, size, err)
+	}
+	if err := rr.UnreadRune(); err != nil {
+		t.Fatalf("UnreadRune() error = %v", err)
+	}
+	if r, _, _ := rr.ReadRune(); r != 'é' {
+		t.Errorf("ReadRune() after unread = %q, want 'é'", r)
+	}
+	if r, _, _ := rr.ReadRune(); r != '1' {
+		t.Errorf("ReadRune() = %q, want '1'", r)
+	}
+	if _, _, err := rr.ReadRune(); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadRune() at end error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,100 @@
+package bytesize
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var bytesType = reflect.TypeFor[Bytes]()
+
+// DecodeStruct sets the Bytes fields of the struct dst points to from the
+// strings getter returns, so that small programs can decode environment
+// variables or maps into config structs. getter is called with each
+// field's name and reports whether a value is present; values are parsed
+// with Parse and opts.
+//
+// A field's "bytesize" struct tag, e.g.
+// `bytesize:"default=1GiB,min=1MiB,max=64GiB"`, controls how it is decoded:
+//
+//   - name=KEY passes KEY to getter instead of the field name
+//   - default=SIZE is used when getter reports no value; without a default
+//     the field is left unchanged
+//   - min=SIZE and max=SIZE bound the decoded value, as MinSize and MaxSize
+//
+// A tag of "-" skips the field, as do unexported fields. DecodeStruct
+// returns every problem found, joined, and each error names its field.
+func DecodeStruct(dst any, getter func(field string) (string, bool), opts ...ParseOption) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("DecodeStruct: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+
+	var errs []error
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		tag, tagged := field.Tag.Lookup("bytesize")
+		if !field.IsExported() || field.Type != bytesType || tag == "-" {
+			if tagged && tag != "-" && field.Type != bytesType {
+				errs = append(errs, fmt.Errorf("field %s: bytesize tag on %s field", field.Name, field.Type))
+			}
+			continue
+		}
+		if err := decodeField(v.Field(i), field.Name, tag, getter, opts); err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", field.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// decodeField decodes one field from its tag and getter.
+func decodeField(v reflect.Value, name, tag string, getter func(string) (string, bool), opts []ParseOption) error {
+	key := name
+	var def string
+	var constraints []Constraint
+	if tag != "" {
+		for item := range strings.SplitSeq(tag, ",") {
+			k, val, ok := strings.Cut(item, "=")
+			if !ok {
+				return fmt.Errorf("invalid tag option %q", item)
+			}
+			switch strings.TrimSpace(k) {
+			case "name":
+				key = val
+			case "default":
+				def = val
+			case "min", "max":
+				bound, err := Parse(val, opts...)
+				if err != nil {
+					return fmt.Errorf("invalid %s in tag: %w", k, err)
+				}
+				if strings.TrimSpace(k) == "min" {
+					constraints = append(constraints, MinSize(bound))
+				} else {
+					constraints = append(constraints, MaxSize(bound))
+				}
+			default:
+				return fmt.Errorf("unknown tag option %q", k)
+			}
+		}
+	}
+
+	s, ok := getter(key)
+	if !ok {
+		if def == "" {
+			return nil
+		}
+		s = def
+	}
+	b, err := Parse(s, opts...)
+	if err != nil {
+		return err
+	}
+	if err := Validate(b, constraints...); err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(b))
+	return nil
+}
@@ -0,0 +1,112 @@
+package bytesize
+
+import (
+	"strings"
+	"testing"
+)
+
+type decodeConfig struct {
+	Cache   Bytes `bytesize:"default=1GiB,min=1MiB,max=64GiB"`
+	Buffer  Bytes `bytesize:"name=BUFFER_SIZE,default=4KiB"`
+	Limit   Bytes
+	Skipped Bytes `bytesize:"-"`
+	Name    string
+	private Bytes
+}
+
+// TestDecodeStruct tests decoding struct fields from a getter
+func TestDecodeStruct(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    decodeConfig
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			values: map[string]string{},
+			want:   decodeConfig{Cache: GiB, Buffer: times(KiB, 4)},
+		},
+		{
+			name:   "values",
+			values: map[string]string{"Cache": "2 GiB", "BUFFER_SIZE": "64KiB", "Limit": "10MB", "Skipped": "1B", "Name": "x", "private": "1B"},
+			want:   decodeConfig{Cache: times(GiB, 2), Buffer: times(KiB, 64), Limit: times(MB, 10)},
+		},
+		{
+			name:    "below min",
+			values:  map[string]string{"Cache": "1KiB"},
+			wantErr: "field Cache: 1.00 KiB is less than the minimum of 1.00 MiB",
+		},
+		{
+			name:    "above max",
+			values:  map[string]string{"Cache": "65GiB"},
+			wantErr: "field Cache: 65.00 GiB is greater than the maximum of 64.00 GiB",
+		},
+		{
+			name:    "invalid values",
+			values:  map[string]string{"Cache": "lots", "Limit": "1 XB"},
+			wantErr: "field Limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got decodeConfig
+			err := DecodeStruct(&got, func(field string) (string, bool) {
+				v, ok := tt.values[field]
+				return v, ok
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeStruct() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeStruct() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeStruct() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDecodeStructInvalid tests DecodeStruct with invalid destinations and
+// tags
+func TestDecodeStructInvalid(t *testing.T) {
+	none := func(string) (string, bool) { return "", false }
+	var cfg decodeConfig
+	var badTag struct {
+		Size Bytes `bytesize:"maximum=1GiB"`
+	}
+	var badBound struct {
+		Size Bytes `bytesize:"min=lots"`
+	}
+	var wrongType struct {
+		Size int64 `bytesize:"default=1GiB"`
+	}
+
+	tests := []struct {
+		name    string
+		dst     any
+		wantErr string
+	}{
+		{"nil", nil, "non-nil pointer to a struct"},
+		{"non-pointer", cfg, "non-nil pointer to a struct"},
+		{"nil pointer", (*decodeConfig)(nil), "non-nil pointer to a struct"},
+		{"pointer to non-struct", new(int), "non-nil pointer to a struct"},
+		{"unknown tag option", &badTag, `field Size: unknown tag option "maximum"`},
+		{"invalid bound", &badBound, "field Size: invalid min in tag"},
+		{"wrong type", &wrongType, "field Size: bytesize tag on int64 field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeStruct(tt.dst, none)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("DecodeStruct() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
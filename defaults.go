@@ -0,0 +1,46 @@
+package bytesize
+
+import "sync/atomic"
+
+// defaultFormatOptions holds the options set by SetDefaultFormatOptions.
+var defaultFormatOptions atomic.Pointer[[]FormatOption]
+
+// SetDefaultFormatOptions sets options that apply to every Format,
+// Formatter and String call in the process, after the Default variables
+// and before any options passed at the call site. For example,
+//
+//	bytesize.SetDefaultFormatOptions(
+//		bytesize.WithDecimalUnits(false),
+//		bytesize.WithFormatString("%.1f %s"),
+//	)
+//
+// makes String use IEC units with one decimal. Each call replaces the
+// options of the previous one, and calling it with no options restores the
+// defaults. It returns an error, leaving the defaults unchanged, if any of
+// the options are invalid. It is safe to call concurrently with
+// formatting, but it is meant to be called once at startup; libraries
+// should pass options or use a Formatter instead.
+func SetDefaultFormatOptions(opts ...FormatOption) error {
+	formatOptions := newFormatOptions()
+	for _, opt := range opts {
+		if err := opt(formatOptions); err != nil {
+			return err
+		}
+	}
+	opts = append([]FormatOption(nil), opts...)
+	defaultFormatOptions.Store(&opts)
+	return nil
+}
+
+// applyDefaultFormatOptions applies the options set by
+// SetDefaultFormatOptions to formatOptions.
+func applyDefaultFormatOptions(formatOptions *formatOptions) {
+	opts := defaultFormatOptions.Load()
+	if opts == nil {
+		return
+	}
+	for _, opt := range *opts {
+		// The options were validated by SetDefaultFormatOptions
+		_ = opt(formatOptions)
+	}
+}
@@ -0,0 +1,41 @@
+package bytesize
+
+import "testing"
+
+// TestSetDefaultFormatOptions tests that process-wide default options apply
+// to String, Format and Formatter and can be overridden and reset
+func TestSetDefaultFormatOptions(t *testing.T) {
+	defer SetDefaultFormatOptions()
+
+	if err := SetDefaultFormatOptions(WithDecimalUnits(false), WithFormatString("%.1f %s")); err != nil {
+		t.Fatalf("SetDefaultFormatOptions() error = %v", err)
+	}
+
+	if got := (Bytes{1536, 0}).String(); got != "1.5 KiB" {
+		t.Errorf("String() = %q, want %q", got, "1.5 KiB")
+	}
+	if got, _ := GB.Format(WithDecimalUnits(true)); got != "1.0 GB" {
+		t.Errorf("Format(WithDecimalUnits(true)) = %q, want %q", got, "1.0 GB")
+	}
+	f, err := NewFormatter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Format(MiB); got != "1.0 MiB" {
+		t.Errorf("Formatter.Format() = %q, want %q", got, "1.0 MiB")
+	}
+
+	if err := SetDefaultFormatOptions(WithFormatString("")); err == nil {
+		t.Error("SetDefaultFormatOptions() with an invalid option returned no error")
+	}
+	if got := MiB.String(); got != "1.0 MiB" {
+		t.Errorf("String() = %q after a failed SetDefaultFormatOptions, want %q", got, "1.0 MiB")
+	}
+
+	if err := SetDefaultFormatOptions(); err != nil {
+		t.Fatal(err)
+	}
+	if got := MiB.String(); got != "1.05 MB" {
+		t.Errorf("String() = %q after resetting the defaults, want %q", got, "1.05 MB")
+	}
+}
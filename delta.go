@@ -0,0 +1,73 @@
+package bytesize
+
+import "fmt"
+
+// Delta is a signed difference between two sizes, which Bytes cannot hold
+// because it is unsigned.
+type Delta struct {
+	// Magnitude is the size of the difference.
+	Magnitude Bytes
+	// Negative reports whether the difference is a decrease. It is false
+	// when Magnitude is zero.
+	Negative bool
+}
+
+// Diff returns the change from from to to, i.e. to - from.
+func Diff(from, to Bytes) Delta {
+	return Delta{Magnitude: Bytes(absDiff(from, to)), Negative: Uint128(to).CmpBytes(from) < 0}
+}
+
+// IsZero reports whether d is no change.
+func (d Delta) IsZero() bool {
+	return Uint128(d.Magnitude).IsZero()
+}
+
+// String returns the magnitude formatted as by Bytes.String with a sign,
+// e.g. "+1.50 GB" or "-512.00 KB", and no sign when it is zero.
+func (d Delta) String() string {
+	switch {
+	case d.IsZero():
+		return d.Magnitude.String()
+	case d.Negative:
+		return "-" + d.Magnitude.String()
+	default:
+		return "+" + d.Magnitude.String()
+	}
+}
+
+// Format formats the magnitude like Bytes.Format with opts, with a sign as
+// in String.
+func (d Delta) Format(opts ...FormatOption) (string, error) {
+	s, err := d.Magnitude.Format(opts...)
+	if err != nil || d.IsZero() {
+		return s, err
+	}
+	if d.Negative {
+		return "-" + s, nil
+	}
+	return "+" + s, nil
+}
+
+// FormatDelta formats the change from before to after for change reports,
+// as an arrow, the magnitude formatted like Bytes.Format with opts and the
+// change as a percentage of before with one decimal: "↑ 1.20 GB (+3.4%)",
+// "↓ 200.00 MB (−1.1%)" with a minus sign (U+2212), or "= 0.00 B (0.0%)"
+// when nothing changed. The percentage is left out when before is zero.
+func FormatDelta(before, after Bytes, opts ...FormatOption) (string, error) {
+	d := Diff(before, after)
+	size, err := d.Magnitude.Format(opts...)
+	if err != nil {
+		return "", err
+	}
+	arrow, sign := "↑", "+"
+	switch {
+	case d.IsZero():
+		arrow, sign = "=", ""
+	case d.Negative:
+		arrow, sign = "↓", "−"
+	}
+	if Uint128(before).IsZero() {
+		return arrow + " " + size, nil
+	}
+	return fmt.Sprintf("%s %s (%s%s)", arrow, size, sign, FormatPercent(d.Magnitude, before, 1)), nil
+}
@@ -0,0 +1,94 @@
+package bytesize
+
+import "testing"
+
+// TestDiff tests signed differences between sizes
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		from, to Bytes
+		want     Delta
+		wantStr  string
+	}{
+		{GB, times(GB, 3), Delta{times(GB, 2), false}, "+2.00 GB"},
+		{times(GB, 3), GB, Delta{times(GB, 2), true}, "-2.00 GB"},
+		{GB, GB, Delta{}, "0.00 B"},
+		{None, Bytes(Max), Delta{Bytes(Max), false}, "+340282366.92 QB"},
+		{Bytes(Max), None, Delta{Bytes(Max), true}, "-340282366.92 QB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantStr, func(t *testing.T) {
+			got := Diff(tt.from, tt.to)
+			if got != tt.want {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+			if s := got.String(); s != tt.wantStr {
+				t.Errorf("String() = %q, want %q", s, tt.wantStr)
+			}
+			if got.IsZero() != (tt.from == tt.to) {
+				t.Errorf("IsZero() = %v", got.IsZero())
+			}
+		})
+	}
+}
+
+// TestDeltaFormat tests formatting deltas with options
+func TestDeltaFormat(t *testing.T) {
+	tests := []struct {
+		delta Delta
+		want  string
+	}{
+		{Delta{MiB, false}, "+1.00 MiB"},
+		{Delta{MiB, true}, "-1.00 MiB"},
+		{Delta{None, true}, "0.00 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := tt.delta.Format(WithDecimalUnits(false))
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (Delta{Magnitude: KB}).Format(WithForcedUnit(Bytes{3, 0})); err == nil {
+		t.Error("Format() with an invalid option returned no error")
+	}
+}
+
+// TestFormatDelta tests formatting the change between two sizes
+func TestFormatDelta(t *testing.T) {
+	tests := []struct {
+		before, after Bytes
+		opts          []FormatOption
+		want          string
+	}{
+		{times(GB, 35), Bytes{36_200_000_000, 0}, nil, "↑ 1.20 GB (+3.4%)"},
+		{times(MB, 18_000), Bytes{17_800_000_000, 0}, nil, "↓ 200.00 MB (−1.1%)"},
+		{GB, GB, nil, "= 0.00 B (0.0%)"},
+		{None, GiB, nil, "↑ 1.07 GB"},
+		{None, None, nil, "= 0.00 B"},
+		{GiB, times(GiB, 2), []FormatOption{WithDecimalUnits(false), WithFormatString("%.1f %s")}, "↑ 1.0 GiB (+100.0%)"},
+		{GB, None, nil, "↓ 1.00 GB (−100.0%)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := FormatDelta(tt.before, tt.after, tt.opts...)
+			if err != nil {
+				t.Fatalf("FormatDelta() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatDelta() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := FormatDelta(KB, MB, WithFormatString("")); err == nil {
+		t.Errorf("FormatDelta() with an invalid option should have errored")
+	}
+}
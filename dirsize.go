@@ -0,0 +1,110 @@
+package bytesize
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArchiveSize returns the total size of the regular files in the tree of
+// fsys rooted at root, which is the uncompressed payload of a tar or zip
+// archive of that tree, for checking space before packing a backup.
+// Directories, symbolic links and other special files count as nothing,
+// and symbolic links are not followed. It stops at the first error.
+func ArchiveSize(fsys fs.FS, root string) (Bytes, error) {
+	var total Uint128
+	err := fs.WalkDir(fsys, root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total = total.Add64(uint64(info.Size()))
+		return nil
+	})
+	if err != nil {
+		return Bytes{}, err
+	}
+	return Bytes(total), nil
+}
+
+// DirSize is ArchiveSize for the directory dir of the operating system's
+// file system.
+func DirSize(dir string) (Bytes, error) {
+	return ArchiveSize(os.DirFS(dir), ".")
+}
+
+// ArchiveSizeByExt is like ArchiveSize, but totals the files separately by
+// extension, for reports of what takes up the space in a tree. The keys
+// are lowercased extensions with the dot, such as ".log", and "" for files
+// without one.
+func ArchiveSizeByExt(fsys fs.FS, root string) (map[string]Bytes, error) {
+	totals := make(map[string]Bytes)
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(path.Ext(name))
+		totals[ext] = Bytes(Uint128(totals[ext]).Add64(uint64(info.Size())))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// DirSizeByExt is ArchiveSizeByExt for the directory dir of the operating
+// system's file system.
+func DirSizeByExt(dir string) (map[string]Bytes, error) {
+	return ArchiveSizeByExt(os.DirFS(dir), ".")
+}
+
+// FSSize returns the total size of the regular files in fsys, such as an
+// embed.FS, a zip.Reader or an fstest.MapFS, like ArchiveSize of its root.
+func FSSize(fsys fs.FS) (Bytes, error) {
+	return ArchiveSize(fsys, ".")
+}
+
+// FSSizeByDir returns the total size of the regular files under each
+// directory of fsys, as du reports it: every directory's total includes
+// its subdirectories, and "." is the total of fsys. Empty directories map
+// to zero.
+func FSSizeByDir(fsys fs.FS) (map[string]Bytes, error) {
+	totals := make(map[string]Bytes)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			totals[name] = Bytes{}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		// Add the file to every directory above it
+		for dir := path.Dir(name); ; dir = path.Dir(dir) {
+			totals[dir] = Bytes(Uint128(totals[dir]).Add64(uint64(info.Size())))
+			if dir == "." {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,173 @@
+package bytesize
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// TestArchiveSize tests summing the sizes of the files in a tree
+func TestArchiveSize(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":          {Data: make([]byte, 100)},
+		"dir/b.bin":      {Data: make([]byte, 2000)},
+		"dir/sub/c.bin":  {Data: make([]byte, 30)},
+		"dir/empty":      {},
+		"dir/link":       {Data: []byte("a.txt"), Mode: fs.ModeSymlink},
+		"other/d.bin":    {Data: make([]byte, 5)},
+		"other/emptydir": {Mode: fs.ModeDir},
+	}
+
+	tests := []struct {
+		root string
+		want uint64
+	}{
+		{".", 2135},
+		{"dir", 2030},
+		{"dir/sub", 30},
+		{"a.txt", 100},
+		{"other/emptydir", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.root, func(t *testing.T) {
+			got, err := ArchiveSize(fsys, tt.root)
+			if err != nil {
+				t.Fatalf("ArchiveSize(%q) error = %v", tt.root, err)
+			}
+			if got != (Bytes{tt.want, 0}) {
+				t.Errorf("ArchiveSize(%q) = %v, want %d bytes", tt.root, Uint128(got), tt.want)
+			}
+		})
+	}
+
+	if _, err := ArchiveSize(fsys, "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ArchiveSize(missing) error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+// TestDirSize tests summing the sizes of the files in a directory
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, size := range map[string]int{"a": 10, "sub/b": 1024} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() error = %v", err)
+	}
+	if got != (Bytes{1034, 0}) {
+		t.Errorf("DirSize() = %v, want 1034 bytes", Uint128(got))
+	}
+}
+
+// TestArchiveSizeByExt tests totaling the sizes of the files in a tree by
+// extension
+func TestArchiveSizeByExt(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":         {Data: make([]byte, 100)},
+		"b.TXT":         {Data: make([]byte, 10)},
+		"dir/c.bin":     {Data: make([]byte, 2000)},
+		"dir/sub/d.bin": {Data: make([]byte, 30)},
+		"dir/Makefile":  {Data: make([]byte, 7)},
+		"dir/link.txt":  {Data: []byte("a.txt"), Mode: fs.ModeSymlink},
+		"dir.d/e":       {Data: make([]byte, 1)},
+	}
+
+	got, err := ArchiveSizeByExt(fsys, ".")
+	if err != nil {
+		t.Fatalf("ArchiveSizeByExt() error = %v", err)
+	}
+	want := map[string]Bytes{
+		".txt": {110, 0},
+		".bin": {2030, 0},
+		"":     {8, 0},
+	}
+	if len(got) != len(want) {
+		t.Errorf("ArchiveSizeByExt() = %v, want %v", got, want)
+	}
+	for ext, size := range want {
+		if got[ext] != size {
+			t.Errorf("ArchiveSizeByExt()[%q] = %v, want %v", ext, Uint128(got[ext]), Uint128(size))
+		}
+	}
+
+	if _, err := ArchiveSizeByExt(fsys, "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ArchiveSizeByExt(missing) error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+// TestDirSizeByExt tests totaling the sizes of the files in a directory by
+// extension
+func TestDirSizeByExt(t *testing.T) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{"a.log": 100, "b.log": 50, "c.gz": 7} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := DirSizeByExt(dir)
+	if err != nil {
+		t.Fatalf("DirSizeByExt() error = %v", err)
+	}
+	if got[".log"] != (Bytes{150, 0}) || got[".gz"] != (Bytes{7, 0}) || len(got) != 2 {
+		t.Errorf("DirSizeByExt() = %v, want .log 150 B and .gz 7 B", got)
+	}
+}
+
+// TestFSSize tests totaling the sizes of the files in a file system
+func TestFSSize(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":         {Data: make([]byte, 100)},
+		"dir/b.bin":     {Data: make([]byte, 2000)},
+		"dir/sub/c.bin": {Data: make([]byte, 30)},
+		"dir/link":      {Data: []byte("a.txt"), Mode: fs.ModeSymlink},
+	}
+	got, err := FSSize(fsys)
+	if err != nil || got != (Bytes{2130, 0}) {
+		t.Errorf("FSSize() = %v, %v, want 2130 bytes", Uint128(got), err)
+	}
+}
+
+// TestFSSizeByDir tests totaling the sizes of the files under each
+// directory of a file system
+func TestFSSizeByDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":          {Data: make([]byte, 100)},
+		"dir/b.bin":      {Data: make([]byte, 2000)},
+		"dir/sub/c.bin":  {Data: make([]byte, 30)},
+		"dir/link":       {Data: []byte("a.txt"), Mode: fs.ModeSymlink},
+		"other/d.bin":    {Data: make([]byte, 5)},
+		"other/emptydir": {Mode: fs.ModeDir},
+	}
+
+	got, err := FSSizeByDir(fsys)
+	if err != nil {
+		t.Fatalf("FSSizeByDir() error = %v", err)
+	}
+	want := map[string]Bytes{
+		".":              {2135, 0},
+		"dir":            {2030, 0},
+		"dir/sub":        {30, 0},
+		"other":          {5, 0},
+		"other/emptydir": {},
+	}
+	if len(got) != len(want) {
+		t.Errorf("FSSizeByDir() = %v, want %v", got, want)
+	}
+	for dir, size := range want {
+		if got[dir] != size {
+			t.Errorf("FSSizeByDir()[%q] = %v, want %v", dir, Uint128(got[dir]), Uint128(size))
+		}
+	}
+}
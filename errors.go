@@ -0,0 +1,27 @@
+package bytesize
+
+import "errors"
+
+// ErrRange is returned, wrapped in a RangeError, when a value is out of the
+// range of the type it is converted to.
+var ErrRange = errors.New("value out of range")
+
+// RangeError reports a value that is out of range for a conversion, such
+// as a size too large for an int64 column or a negative column value.
+type RangeError struct {
+	// Func is the name of the function that failed, e.g. "Int64Column".
+	Func string
+	// Value is the out of range value, in base 10.
+	Value string
+}
+
+// Error implements the error interface.
+func (e *RangeError) Error() string {
+	return "bytesize." + e.Func + ": " + e.Value + ": " + ErrRange.Error()
+}
+
+// Unwrap returns ErrRange, so that errors.Is(err, ErrRange) matches every
+// RangeError.
+func (e *RangeError) Unwrap() error {
+	return ErrRange
+}
@@ -0,0 +1,222 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Eval evaluates an arithmetic expression over sizes, such as
+// "limit * 0.8", "total - used" or "(1 GiB + 512 MiB) / 2". Operands are
+// size literals written as for Parse, plain numbers, and the names of vars.
+// It supports +, -, * and / with the usual precedence and parentheses.
+//
+// Sizes may be added to and subtracted from sizes, multiplied or divided by
+// numbers, and divided by sizes to give a number. The arithmetic is exact;
+// the result must be a size, and any fraction of a byte is truncated as in
+// Parse. It returns a *SyntaxError for a malformed expression and an error
+// for an unknown variable, a type mismatch, division by zero or a result
+// that is negative or overflows.
+func Eval(expr string, vars map[string]Bytes) (Bytes, error) {
+	e := &evaluator{input: expr, vars: vars}
+	v, err := e.expr()
+	if err != nil {
+		return Bytes{}, err
+	}
+	e.skipSpace()
+	if e.pos < len(e.input) {
+		return Bytes{}, e.syntaxError("unexpected %q", e.peek())
+	}
+	if !v.size {
+		return Bytes{}, fmt.Errorf("expression is a number, not a size: %s", v.r.RatString())
+	}
+	if v.r.Sign() < 0 {
+		return Bytes{}, fmt.Errorf("negative value: %s", v.r.RatString())
+	}
+
+	whole := new(big.Int).Quo(v.r.Num(), v.r.Denom())
+	u, err := FromBigErr(whole)
+	if err != nil {
+		return Bytes{}, fmt.Errorf("value overflows Uint128: result is %d bits", whole.BitLen())
+	}
+	return Bytes(u), nil
+}
+
+// evalValue is an intermediate value of an expression: an exact number of
+// bytes if size is true, and otherwise a dimensionless number.
+type evalValue struct {
+	r    *big.Rat
+	size bool
+}
+
+// evaluator is a recursive descent evaluator for the grammar
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ("*" | "/") factor }
+//	factor = number [ unit ] | name | "(" expr ")"
+type evaluator struct {
+	input string
+	pos   int
+	vars  map[string]Bytes
+}
+
+func (e *evaluator) expr() (evalValue, error) {
+	left, err := e.term()
+	if err != nil {
+		return evalValue{}, err
+	}
+	for {
+		e.skipSpace()
+		op := e.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		e.pos++
+		right, err := e.term()
+		if err != nil {
+			return evalValue{}, err
+		}
+		if left.size != right.size {
+			return evalValue{}, fmt.Errorf("cannot %s a size and a number", opVerb(op))
+		}
+		if op == '+' {
+			left.r.Add(left.r, right.r)
+		} else {
+			left.r.Sub(left.r, right.r)
+		}
+	}
+}
+
+func (e *evaluator) term() (evalValue, error) {
+	left, err := e.factor()
+	if err != nil {
+		return evalValue{}, err
+	}
+	for {
+		e.skipSpace()
+		op := e.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		e.pos++
+		right, err := e.factor()
+		if err != nil {
+			return evalValue{}, err
+		}
+		if op == '*' {
+			if left.size && right.size {
+				return evalValue{}, fmt.Errorf("cannot multiply two sizes")
+			}
+			left.r.Mul(left.r, right.r)
+			left.size = left.size || right.size
+			continue
+		}
+		if right.size && !left.size {
+			return evalValue{}, fmt.Errorf("cannot divide a number by a size")
+		}
+		if right.r.Sign() == 0 {
+			return evalValue{}, fmt.Errorf("division by zero")
+		}
+		left.r.Quo(left.r, right.r)
+		left.size = left.size && !right.size
+	}
+}
+
+func (e *evaluator) factor() (evalValue, error) {
+	e.skipSpace()
+	start := e.pos
+	switch r := e.peek(); {
+	case r == '(':
+		e.pos++
+		v, err := e.expr()
+		if err != nil {
+			return evalValue{}, err
+		}
+		e.skipSpace()
+		if e.peek() != ')' {
+			return evalValue{}, e.syntaxError("missing ')'")
+		}
+		e.pos++
+		return v, nil
+	case r == '.' || ('0' <= r && r <= '9'):
+		for e.pos < len(e.input) && (e.input[e.pos] == '.' || ('0' <= e.input[e.pos] && e.input[e.pos] <= '9')) {
+			e.pos++
+		}
+		num, ok := new(big.Rat).SetString(e.input[start:e.pos])
+		if !ok {
+			return evalValue{}, &SyntaxError{Input: e.input, Offset: start, Msg: "invalid number"}
+		}
+		e.skipSpace()
+		unitStart := e.pos
+		unit := e.name()
+		if unit == "" {
+			return evalValue{r: num}, nil
+		}
+		multiplier, ok := lookupUnit(unit)
+		if !ok {
+			return evalValue{}, &SyntaxError{Input: e.input, Offset: unitStart, Msg: "unknown unit " + unit}
+		}
+		return evalValue{r: num.Mul(num, bigMultiplierOf(multiplier, new(ratScratch)).r), size: true}, nil
+	case isNameStart(r):
+		name := e.name()
+		b, ok := e.vars[name]
+		if !ok {
+			return evalValue{}, fmt.Errorf("unknown variable: %s", name)
+		}
+		return evalValue{r: new(big.Rat).SetInt(Uint128(b).Big()), size: true}, nil
+	case r == utf8.RuneError && e.pos >= len(e.input):
+		return evalValue{}, e.syntaxError("unexpected end of expression")
+	default:
+		return evalValue{}, e.syntaxError("unexpected %q", r)
+	}
+}
+
+// name consumes and returns a name, which is empty if the input does not
+// continue with one.
+func (e *evaluator) name() string {
+	start := e.pos
+	for e.pos < len(e.input) {
+		r, size := utf8.DecodeRuneInString(e.input[e.pos:])
+		if !isNameStart(r) && (e.pos == start || !unicode.IsDigit(r)) {
+			break
+		}
+		e.pos += size
+	}
+	return e.input[start:e.pos]
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func (e *evaluator) skipSpace() {
+	for e.pos < len(e.input) {
+		r, size := utf8.DecodeRuneInString(e.input[e.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		e.pos += size
+	}
+}
+
+// peek returns the next rune without consuming it, or utf8.RuneError at
+// the end of the input.
+func (e *evaluator) peek() rune {
+	if e.pos >= len(e.input) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(e.input[e.pos:])
+	return r
+}
+
+func (e *evaluator) syntaxError(format string, args ...any) error {
+	return &SyntaxError{Input: e.input, Offset: e.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func opVerb(op rune) string {
+	if op == '+' {
+		return "add"
+	}
+	return "subtract"
+}
@@ -0,0 +1,90 @@
+package bytesize
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestEval tests evaluating size expressions
+func TestEval(t *testing.T) {
+	vars := map[string]Bytes{
+		"limit": GB,
+		"total": Bytes{10_000, 0},
+		"used":  Bytes{2_500, 0},
+		"max":   Bytes(Max),
+	}
+
+	tests := []struct {
+		expr string
+		want Bytes
+	}{
+		{"limit * 0.8", Bytes{800_000_000, 0}},
+		{"0.8 * limit", Bytes{800_000_000, 0}},
+		{"total - used", Bytes{7_500, 0}},
+		{"(1 GiB + 512 MiB) / 2", Bytes(Uint128(MiB).Mul64(768))},
+		{"1.5GiB", Bytes(Uint128(MiB).Mul64(1536))},
+		{"10 kilobytes", Bytes{10_000, 0}},
+		{"total - used * 2", Bytes{5_000, 0}},
+		{"(total - used) * 2", Bytes{15_000, 0}},
+		{"used / total * limit", Bytes{250_000_000, 0}},
+		{"limit / 3", Bytes{333_333_333, 0}},
+		{"limit / 3 * 3", GB},
+		{"max - 1 B + 1 B", Bytes(Max)},
+		{"  used  ", Bytes{2_500, 0}},
+		{"used - used", None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Eval(tt.expr, vars)
+			if err != nil {
+				t.Fatalf("Eval(%q) error = %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval(%q) = %v, want %v", tt.expr, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestEvalErrors tests that Eval rejects malformed and ill-typed
+// expressions
+func TestEvalErrors(t *testing.T) {
+	vars := map[string]Bytes{"a": KB, "zero": None}
+
+	tests := []struct {
+		expr   string
+		syntax bool
+	}{
+		{"", true},
+		{"a +", true},
+		{"(a", true},
+		{"a )", true},
+		{"1.2.3 KB", true},
+		{"3 XB", true},
+		{"a % 2", true},
+		{"b", false},
+		{"2 * 3", false},
+		{"a + 1", false},
+		{"a * a", false},
+		{"2 / a", false},
+		{"a / zero", false},
+		{"a / 0", false},
+		{"a - 2 KB", false},
+		{"a / a", false},
+		{"340282366920938463463374607431768211455 B + 1 B", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Eval(tt.expr, vars)
+			if err == nil {
+				t.Fatalf("Eval(%q) returned no error", tt.expr)
+			}
+			var syntaxErr *SyntaxError
+			if got := errors.As(err, &syntaxErr); got != tt.syntax {
+				t.Errorf("Eval(%q) error = %v, want syntax error %v", tt.expr, err, tt.syntax)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package bytesize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatExactBytes formats b as its exact number of bytes with the digits
+// grouped in threes by sep, e.g. "1 234 567 890 B" for a space or
+// "1,234,567,890 B" for a comma, for audit logs that must show precise
+// counts. A sep of 0 leaves the digits ungrouped.
+func (b Bytes) FormatExactBytes(sep rune) string {
+	digits := Uint128(b).String()
+	if sep == 0 || len(digits) <= 3 {
+		return digits + " B"
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(digits) + (len(digits)-1)/3*utf8.RuneLen(sep) + 2)
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	sb.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		sb.WriteRune(sep)
+		sb.WriteString(digits[i : i+3])
+	}
+	sb.WriteString(" B")
+	return sb.String()
+}
@@ -0,0 +1,30 @@
+package bytesize
+
+import "testing"
+
+// TestFormatExactBytes tests formatting exact byte counts with grouping
+func TestFormatExactBytes(t *testing.T) {
+	tests := []struct {
+		b    Bytes
+		sep  rune
+		want string
+	}{
+		{Bytes{1_234_567_890, 0}, ' ', "1 234 567 890 B"},
+		{Bytes{1_234_567_890, 0}, ',', "1,234,567,890 B"},
+		{Bytes{1_234_567_890, 0}, '\u202f', "1\u202f234\u202f567\u202f890 B"},
+		{Bytes{1_234_567_890, 0}, 0, "1234567890 B"},
+		{None, ',', "0 B"},
+		{Bytes{999, 0}, ',', "999 B"},
+		{KB, ',', "1,000 B"},
+		{Bytes{123_456, 0}, ',', "123,456 B"},
+		{Bytes(Max), ',', "340,282,366,920,938,463,463,374,607,431,768,211,455 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.b.FormatExactBytes(tt.sep); got != tt.want {
+				t.Errorf("FormatExactBytes(%q) = %q, want %q", tt.sep, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package bytesize
+
+import "os"
+
+// FileSizes returns the apparent size of the file at path, the length that
+// reading it yields, and its allocated size, the disk space its blocks
+// take. A sparse file's allocated size is less than its apparent size,
+// while a small file's is usually more, rounded up to whole blocks, so
+// deduplication and backup tools can report both. Symbolic links are
+// followed. On systems without block counts, the allocated size is the
+// apparent size.
+func FileSizes(path string) (apparent, allocated Bytes, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Bytes{}, Bytes{}, err
+	}
+	apparent = Bytes{uint64(info.Size()), 0}
+	return apparent, allocatedSize(info, apparent), nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package bytesize
+
+import "io/fs"
+
+// allocatedSize returns apparent, as block counts are not available.
+func allocatedSize(_ fs.FileInfo, apparent Bytes) Bytes {
+	return apparent
+}
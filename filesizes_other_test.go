@@ -0,0 +1,21 @@
+//go:build !unix
+
+package bytesize
+
+import "testing"
+
+// checkAllocated checks that the allocated size of a file is its apparent
+// size, as block counts are not available.
+func checkAllocated(t *testing.T, path string, apparent, allocated Bytes) {
+	t.Helper()
+	if allocated != apparent {
+		t.Errorf("FileSizes(%q) allocated = %s, want %s", path, allocated, apparent)
+	}
+}
+
+// checkSparseAllocated checks a sparse file like any other, as sparse files
+// cannot be told apart without block counts.
+func checkSparseAllocated(t *testing.T, path string, apparent, allocated Bytes) {
+	t.Helper()
+	checkAllocated(t, path, apparent, allocated)
+}
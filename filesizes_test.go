@@ -0,0 +1,51 @@
+package bytesize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileSizes tests the apparent and allocated sizes of regular and
+// sparse files. The allocated sizes are checked by platform.
+func TestFileSizes(t *testing.T) {
+	dir := t.TempDir()
+
+	small := filepath.Join(dir, "small")
+	if err := os.WriteFile(small, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	apparent, allocated, err := FileSizes(small)
+	if err != nil {
+		t.Fatalf("FileSizes() error = %v", err)
+	}
+	if apparent != (Bytes{5, 0}) {
+		t.Errorf("FileSizes() apparent = %s, want 5 B", apparent)
+	}
+	checkAllocated(t, small, apparent, allocated)
+
+	sparse := filepath.Join(dir, "sparse")
+	f, err := os.Create(sparse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Truncate(64 << 20); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	apparent, allocated, err = FileSizes(sparse)
+	if err != nil {
+		t.Fatalf("FileSizes() error = %v", err)
+	}
+	if apparent != times(MiB, 64) {
+		t.Errorf("FileSizes() apparent = %s, want 64 MiB", apparent)
+	}
+	checkSparseAllocated(t, sparse, apparent, allocated)
+}
+
+// TestFileSizesMissing tests that missing files are reported
+func TestFileSizesMissing(t *testing.T) {
+	if _, _, err := FileSizes(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("FileSizes() error = %v, want a not-exist error", err)
+	}
+}
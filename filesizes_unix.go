@@ -0,0 +1,19 @@
+//go:build unix
+
+package bytesize
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// allocatedSize returns the allocated size of the file described by info
+// from its st_blocks, which counts 512-byte blocks on every Unix, or
+// apparent if info does not come from stat.
+func allocatedSize(info fs.FileInfo, apparent Bytes) Bytes {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return apparent
+	}
+	return Bytes(Uint128{uint64(st.Blocks), 0}.Mul64(512))
+}
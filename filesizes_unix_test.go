@@ -0,0 +1,44 @@
+//go:build unix
+
+package bytesize
+
+import (
+	"syscall"
+	"testing"
+)
+
+// statBlocks returns the allocated size of the file at path as stat
+// reports it.
+func statBlocks(t *testing.T, path string) Bytes {
+	t.Helper()
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		t.Fatal(err)
+	}
+	return Bytes{uint64(st.Blocks) * 512, 0}
+}
+
+// checkAllocated checks the allocated size of a regular file, which is a
+// whole number of 512-byte blocks.
+func checkAllocated(t *testing.T, path string, _, allocated Bytes) {
+	t.Helper()
+	if want := statBlocks(t, path); allocated != want {
+		t.Errorf("FileSizes(%q) allocated = %s, want %s", path, allocated, want)
+	}
+	if Uint128(allocated).Lo%512 != 0 {
+		t.Errorf("FileSizes(%q) allocated = %s, want whole 512-byte blocks", path, allocated)
+	}
+}
+
+// checkSparseAllocated checks that the allocated size of a sparse file is
+// less than its apparent size, where the file system supports sparse files.
+func checkSparseAllocated(t *testing.T, path string, apparent, allocated Bytes) {
+	t.Helper()
+	checkAllocated(t, path, apparent, allocated)
+	if Uint128(statBlocks(t, path)).CmpBytes(apparent) >= 0 {
+		t.Skipf("file system of %s does not support sparse files", path)
+	}
+	if Uint128(allocated).CmpBytes(apparent) >= 0 {
+		t.Errorf("FileSizes(%q) allocated = %s, want less than %s", path, allocated, apparent)
+	}
+}
@@ -0,0 +1,60 @@
+package bytesize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// fixedWidthForms are the forms FormatFixedWidth tries, from the most to
+// the least detailed.
+var fixedWidthForms = []struct {
+	formatStr string
+	longUnits bool
+	letter    bool // abbreviate the unit to its first letter
+}{
+	{"%.2f %s", true, false},  // "1.50 Gigabytes"
+	{"%.1f %s", true, false},  // "1.5 Gigabytes"
+	{"%.2f %s", false, false}, // "1.50 GB"
+	{"%.1f %s", false, false}, // "1.5 GB"
+	{"%.1f%s", false, false},  // "1.5GB"
+	{"%.0f %s", false, false}, // "2 GB"
+	{"%.0f%s", false, false},  // "2GB"
+	{"%.0f%s", false, true},   // "2G"
+}
+
+// FormatFixedWidth formats b to fit a column width characters wide, for
+// terminal dashboards with tight layouts. It uses the most detailed of
+// "1.50 Gigabytes", "1.5 Gigabytes", "1.50 GB", "1.5 GB", "1.5GB", "2 GB",
+// "2GB" and "2G" that fits, right-aligned with leading spaces to exactly
+// width characters. If even the shortest form does not fit, it returns
+// width '#' characters, as spreadsheets do, rather than a misleading
+// truncation. Units are chosen as by String with the package defaults.
+func FormatFixedWidth(b Bytes, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	for _, form := range fixedWidthForms {
+		formatOptions := newFormatOptions()
+		formatOptions.formatStr = form.formatStr
+		formatOptions.forcedUnit = nil
+		formatOptions.longUnits = form.longUnits
+		s := b.formatWith(formatOptions)
+		if form.letter {
+			s = abbreviateUnit(s)
+		}
+		if n := utf8.RuneCountInString(s); n <= width {
+			return strings.Repeat(" ", width-n) + s
+		}
+	}
+	return strings.Repeat("#", width)
+}
+
+// abbreviateUnit shortens the unit after the number in s to its first
+// letter, as in "2G" for "2GB" and "2K" for "2KiB".
+func abbreviateUnit(s string) string {
+	i := strings.LastIndexAny(s, "0123456789") + 1
+	if i < len(s) {
+		return s[:i+1]
+	}
+	return s
+}
@@ -0,0 +1,38 @@
+package bytesize
+
+import "testing"
+
+// TestFormatFixedWidth tests degrading the format to fit a width
+func TestFormatFixedWidth(t *testing.T) {
+	tests := []struct {
+		b     Bytes
+		width int
+		want  string
+	}{
+		{Bytes{1_500_000_000, 0}, 16, "  1.50 Gigabytes"},
+		{Bytes{1_500_000_000, 0}, 14, "1.50 Gigabytes"},
+		{Bytes{1_500_000_000, 0}, 13, "1.5 Gigabytes"},
+		{Bytes{1_500_000_000, 0}, 12, "     1.50 GB"},
+		{Bytes{1_500_000_000, 0}, 7, "1.50 GB"},
+		{Bytes{1_500_000_000, 0}, 6, "1.5 GB"},
+		{Bytes{1_500_000_000, 0}, 5, "1.5GB"},
+		{Bytes{1_500_000_000, 0}, 4, "2 GB"},
+		{Bytes{1_500_000_000, 0}, 3, "2GB"},
+		{Bytes{1_500_000_000, 0}, 2, "2G"},
+		{Bytes{1_500_000_000, 0}, 1, "#"},
+		{Bytes{1_500_000_000, 0}, 0, ""},
+		{Bytes{512, 0}, 4, "512B"},
+		{Bytes{999_600, 0}, 5, "1000K"},
+		{Bytes{999_600, 0}, 4, "####"},
+		{B, 11, "  1.00 Byte"},
+		{Bytes(Max), 6, "######"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatFixedWidth(tt.b, tt.width); got != tt.want {
+				t.Errorf("FormatFixedWidth(%v, %d) = %q, want %q", tt.b, tt.width, got, tt.want)
+			}
+		})
+	}
+}
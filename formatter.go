@@ -0,0 +1,29 @@
+package bytesize
+
+// Formatter formats Bytes values with a fixed set of options. The options
+// are resolved once by NewFormatter rather than on every call as with
+// Bytes.Format. A Formatter is safe for concurrent use.
+type Formatter struct {
+	opts *formatOptions
+}
+
+// NewFormatter returns a Formatter using the specified options. It returns
+// an error if any of the options are invalid.
+func NewFormatter(opts ...FormatOption) (*Formatter, error) {
+	formatOptions, err := resolveFormatOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Formatter{opts: formatOptions}, nil
+}
+
+// Format formats b as a human-readable string.
+func (f *Formatter) Format(b Bytes) string {
+	return b.formatWith(f.opts)
+}
+
+// AppendFormat appends the human-readable form of b to dst and returns the
+// extended buffer.
+func (f *Formatter) AppendFormat(dst []byte, b Bytes) []byte {
+	return b.appendFormatWith(dst, f.opts)
+}
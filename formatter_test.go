@@ -0,0 +1,69 @@
+package bytesize
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestFormatter tests that a Formatter formats like Bytes.Format with the
+// same options
+func TestFormatter(t *testing.T) {
+	values := []Bytes{{}, B, {1536, 0}, GB, QB, Bytes(Max)}
+	optionSets := [][]FormatOption{
+		nil,
+		{WithDecimalUnits(false), WithLongUnits(true)},
+		{WithForcedUnit(MB), WithFormatString("%.3f%s")},
+	}
+
+	for _, opts := range optionSets {
+		f, err := NewFormatter(opts...)
+		if err != nil {
+			t.Fatalf("NewFormatter() error = %v", err)
+		}
+		for _, b := range values {
+			want, err := b.Format(opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := f.Format(b); got != want {
+				t.Errorf("Formatter.Format(%v) = %q, want %q", Uint128(b), got, want)
+			}
+			if got := string(f.AppendFormat([]byte("x="), b)); got != "x="+want {
+				t.Errorf("Formatter.AppendFormat(%v) = %q, want %q", Uint128(b), got, "x="+want)
+			}
+		}
+	}
+}
+
+// TestNewFormatterErrors tests that NewFormatter rejects invalid options
+func TestNewFormatterErrors(t *testing.T) {
+	if _, err := NewFormatter(WithFormatString("")); err == nil {
+		t.Error("NewFormatter(WithFormatString(\"\")) returned no error")
+	}
+	if _, err := NewFormatter(WithForcedUnit(Bytes{3, 0})); err == nil {
+		t.Error("NewFormatter(WithForcedUnit(3)) returned no error")
+	}
+}
+
+// TestFormatterConcurrent tests that a Formatter can be shared between
+// goroutines
+func TestFormatterConcurrent(t *testing.T) {
+	f, err := NewFormatter(WithDecimalUnits(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if got := f.Format(GiB); got != "1.00 GiB" {
+					t.Errorf("Formatter.Format(GiB) = %q", got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
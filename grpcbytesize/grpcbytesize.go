@@ -0,0 +1,269 @@
+// Package grpcbytesize provides gRPC interceptors that measure the
+// marshaled size of messages as bytesize.Bytes, keep running totals and
+// enforce per-call limits. It is a separate module so that importing
+// bytesize does not pull in gRPC.
+package grpcbytesize
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/beauhoyt/bytesize"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Accountant measures the messages of the calls its interceptors see.
+// Sizes are the protobuf wire sizes of the messages, before compression;
+// messages that are not protobuf messages count as nothing. An Accountant
+// is safe for concurrent use, and one Accountant may serve both client and
+// server interceptors.
+type Accountant struct {
+	maxReceive, maxSend bytesize.Bytes
+	received, sent      bytesize.Counter
+}
+
+// Option configures an Accountant.
+type Option func(*Accountant) error
+
+// WithMaxReceive limits the bytes a single call may receive, summed over
+// every message of a stream. A call that receives more fails with
+// codes.ResourceExhausted. A limit of zero means no limit, the default.
+func WithMaxReceive(limit bytesize.Bytes) Option {
+	return func(a *Accountant) error {
+		a.maxReceive = limit
+		return nil
+	}
+}
+
+// WithMaxReceiveString is like WithMaxReceive, but parses the limit from
+// a configuration value such as "4 MiB" with bytesize.Parse and opts.
+func WithMaxReceiveString(limit string, opts ...bytesize.ParseOption) Option {
+	return func(a *Accountant) error {
+		b, err := bytesize.Parse(limit, opts...)
+		if err != nil {
+			return fmt.Errorf("invalid receive limit: %w", err)
+		}
+		a.maxReceive = b
+		return nil
+	}
+}
+
+// WithMaxSend limits the bytes a single call may send, summed over every
+// message of a stream. A message that would take the call over the limit
+// is not sent, and the call fails with codes.ResourceExhausted. A limit of
+// zero means no limit, the default.
+func WithMaxSend(limit bytesize.Bytes) Option {
+	return func(a *Accountant) error {
+		a.maxSend = limit
+		return nil
+	}
+}
+
+// WithMaxSendString is like WithMaxSend, but parses the limit from a
+// configuration value such as "4 MiB" with bytesize.Parse and opts.
+func WithMaxSendString(limit string, opts ...bytesize.ParseOption) Option {
+	return func(a *Accountant) error {
+		b, err := bytesize.Parse(limit, opts...)
+		if err != nil {
+			return fmt.Errorf("invalid send limit: %w", err)
+		}
+		a.maxSend = b
+		return nil
+	}
+}
+
+// New returns an Accountant with the specified options. It returns an
+// error if any of the options are invalid.
+func New(opts ...Option) (*Accountant, error) {
+	a := &Accountant{}
+	for _, opt := range opts {
+		if err := opt(a); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Received returns the total of the messages received: requests on the
+// server and responses on the client.
+func (a *Accountant) Received() *bytesize.Counter {
+	return &a.received
+}
+
+// Sent returns the total of the messages sent: responses on the server
+// and requests on the client.
+func (a *Accountant) Sent() *bytesize.Counter {
+	return &a.sent
+}
+
+// UnaryServerInterceptor returns an interceptor that measures unary
+// requests and responses.
+func (a *Accountant) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var call callSizes
+		if err := a.receive(&call, req); err != nil {
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.send(&call, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that measures the
+// messages of streams.
+func (a *Accountant) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, a: a})
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor that measures unary
+// requests and responses.
+func (a *Accountant) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var call callSizes
+		size, err := a.checkSend(&call, req)
+		if err != nil {
+			return err
+		}
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		a.commitSend(&call, size)
+		return a.receive(&call, reply)
+	}
+}
+
+// StreamClientInterceptor returns an interceptor that measures the
+// messages of streams.
+func (a *Accountant) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, a: a}, nil
+	}
+}
+
+// callSizes is the bytes one call has received and sent. Each field is
+// only touched by the goroutine receiving or sending, which gRPC allows
+// to differ.
+type callSizes struct {
+	received, sent bytesize.Bytes
+}
+
+// messageSize returns the wire size of m, or zero if m is not a protobuf
+// message.
+func messageSize(m any) bytesize.Bytes {
+	if pm, ok := m.(proto.Message); ok {
+		return bytesize.Bytes{Lo: uint64(proto.Size(pm))}
+	}
+	return bytesize.Bytes{}
+}
+
+// receive counts the received message m and checks the call's limit.
+func (a *Accountant) receive(call *callSizes, m any) error {
+	size := messageSize(m)
+	a.received.Add(size)
+	call.received = bytesize.Bytes(bytesize.Uint128(call.received).AddBytes(size))
+	if exceeds(call.received, a.maxReceive) {
+		return status.Errorf(codes.ResourceExhausted, "received %s exceeds the limit of %s", call.received, a.maxReceive)
+	}
+	return nil
+}
+
+// checkSend returns the size of m, which is about to be sent, or an error
+// if it would take the call over its limit. Nothing is counted until
+// commitSend, once the send has succeeded.
+func (a *Accountant) checkSend(call *callSizes, m any) (bytesize.Bytes, error) {
+	size := messageSize(m)
+	total := bytesize.Bytes(bytesize.Uint128(call.sent).AddBytes(size))
+	if exceeds(total, a.maxSend) {
+		return bytesize.Bytes{}, status.Errorf(codes.ResourceExhausted, "sending %s exceeds the limit of %s", total, a.maxSend)
+	}
+	return size, nil
+}
+
+// commitSend counts size bytes sent by the call.
+func (a *Accountant) commitSend(call *callSizes, size bytesize.Bytes) {
+	call.sent = bytesize.Bytes(bytesize.Uint128(call.sent).AddBytes(size))
+	a.sent.Add(size)
+}
+
+// send checks and counts m, which is about to be sent.
+func (a *Accountant) send(call *callSizes, m any) error {
+	size, err := a.checkSend(call, m)
+	if err != nil {
+		return err
+	}
+	a.commitSend(call, size)
+	return nil
+}
+
+// exceeds reports whether total is over limit, where a zero limit means
+// no limit.
+func exceeds(total, limit bytesize.Bytes) bool {
+	return !bytesize.Uint128(limit).IsZero() && bytesize.Uint128(total).CmpBytes(limit) > 0
+}
+
+// serverStream measures the messages of a server stream.
+type serverStream struct {
+	grpc.ServerStream
+	a    *Accountant
+	call callSizes
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	size, err := s.a.checkSend(&s.call, m)
+	if err != nil {
+		return err
+	}
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.a.commitSend(&s.call, size)
+	return nil
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.a.receive(&s.call, m)
+}
+
+// clientStream measures the messages of a client stream.
+type clientStream struct {
+	grpc.ClientStream
+	a    *Accountant
+	call callSizes
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	size, err := s.a.checkSend(&s.call, m)
+	if err != nil {
+		return err
+	}
+	if err := s.ClientStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.a.commitSend(&s.call, size)
+	return nil
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.a.receive(&s.call, m)
+}
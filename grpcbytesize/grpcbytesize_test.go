@@ -0,0 +1,208 @@
+package grpcbytesize
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/beauhoyt/bytesize"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The health check request for "svc" is 5 bytes on the wire and the
+// SERVING response is 2 bytes
+var (
+	requestSize  = bytesize.Bytes{Lo: 5}
+	responseSize = bytesize.Bytes{Lo: 2}
+)
+
+// mustNew returns an Accountant with the specified options, failing the
+// test if they are invalid.
+func mustNew(t *testing.T, opts ...Option) *Accountant {
+	t.Helper()
+	a, err := New(opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return a
+}
+
+// dial starts a health server with the server Accountant and returns a
+// client connected to it with the client Accountant.
+func dial(t *testing.T, server, client *Accountant) healthpb.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(server.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(server.StreamServerInterceptor()),
+	)
+	hs := health.NewServer()
+	hs.SetServingStatus("svc", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, hs)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(client.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(client.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+// checkTotals checks the totals of an Accountant.
+func checkTotals(t *testing.T, name string, a *Accountant, received, sent bytesize.Bytes) {
+	t.Helper()
+	if got := a.Received().Total(); got != received {
+		t.Errorf("%s Received() = %v, want %v", name, got.Lo, received.Lo)
+	}
+	if got := a.Sent().Total(); got != sent {
+		t.Errorf("%s Sent() = %v, want %v", name, got.Lo, sent.Lo)
+	}
+}
+
+// TestUnary tests measuring unary calls on both sides
+func TestUnary(t *testing.T) {
+	server, client := mustNew(t), mustNew(t)
+	hc := dial(t, server, client)
+
+	for range 2 {
+		if _, err := hc.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"}); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+	}
+	twice := func(b bytesize.Bytes) bytesize.Bytes { return bytesize.Bytes(bytesize.Uint128(b).Mul64(2)) }
+	checkTotals(t, "server", server, twice(requestSize), twice(responseSize))
+	checkTotals(t, "client", client, twice(responseSize), twice(requestSize))
+}
+
+// TestStream tests measuring streams on both sides
+func TestStream(t *testing.T) {
+	server, client := mustNew(t), mustNew(t)
+	hc := dial(t, server, client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := hc.Watch(ctx, &healthpb.HealthCheckRequest{Service: "svc"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	checkTotals(t, "client", client, responseSize, requestSize)
+	cancel()
+
+	// The server counts its response only after sending it, which may be
+	// after the client received it
+	deadline := time.Now().Add(5 * time.Second)
+	for server.Sent().Total() != responseSize && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	checkTotals(t, "server", server, requestSize, responseSize)
+}
+
+// TestLimits tests that calls over their limits fail
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		server, client *Accountant
+	}{
+		{"server receive", mustNew(t, WithMaxReceive(bytesize.Bytes{Lo: 4})), mustNew(t)},
+		{"server send", mustNew(t, WithMaxSend(bytesize.Bytes{Lo: 1})), mustNew(t)},
+		{"client send", mustNew(t), mustNew(t, WithMaxSend(bytesize.Bytes{Lo: 4}))},
+		{"client receive", mustNew(t), mustNew(t, WithMaxReceive(bytesize.Bytes{Lo: 1}))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := dial(t, tt.server, tt.client)
+			_, err := hc.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"})
+			if status.Code(err) != codes.ResourceExhausted {
+				t.Errorf("Check() error = %v, want ResourceExhausted", err)
+			}
+		})
+	}
+
+	// Limits at the exact sizes allow the call
+	hc := dial(t, mustNew(t, WithMaxReceive(requestSize), WithMaxSend(responseSize)), mustNew(t, WithMaxReceive(responseSize), WithMaxSend(requestSize)))
+	if _, err := hc.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"}); err != nil {
+		t.Errorf("Check() at the limits error = %v", err)
+	}
+}
+
+// TestClientSendLimitNotSent tests that a request over the client's limit
+// is never sent
+func TestClientSendLimitNotSent(t *testing.T) {
+	server, client := mustNew(t), mustNew(t, WithMaxSend(bytesize.Bytes{Lo: 4}))
+	hc := dial(t, server, client)
+	_, _ = hc.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"})
+	checkTotals(t, "server", server, bytesize.None, bytesize.None)
+	checkTotals(t, "client", client, bytesize.None, bytesize.None)
+}
+
+// TestClientFailedSendNotCounted tests that a call that fails does not
+// count its request as sent
+func TestClientFailedSendNotCounted(t *testing.T) {
+	server, client := mustNew(t, WithMaxReceive(bytesize.Bytes{Lo: 4})), mustNew(t)
+	hc := dial(t, server, client)
+	if _, err := hc.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"}); err == nil {
+		t.Fatalf("Check() should have errored")
+	}
+	checkTotals(t, "client", client, bytesize.None, bytesize.None)
+}
+
+// TestStreamFailedSendNotCounted tests that a stream message that fails to
+// send counts toward neither the total nor the call's limit
+func TestStreamFailedSendNotCounted(t *testing.T) {
+	a := mustNew(t, WithMaxSend(requestSize))
+	s := &clientStream{ClientStream: failingStream{}, a: a}
+	req := &healthpb.HealthCheckRequest{Service: "svc"}
+	if err := s.SendMsg(req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("SendMsg() error = %v, want Unavailable", err)
+	}
+	checkTotals(t, "client", a, bytesize.None, bytesize.None)
+	if s.call.sent != bytesize.None {
+		t.Errorf("call sent = %v, want 0", s.call.sent.Lo)
+	}
+}
+
+// failingStream is a client stream whose sends fail.
+type failingStream struct {
+	grpc.ClientStream
+}
+
+func (failingStream) SendMsg(any) error {
+	return status.Error(codes.Unavailable, "unavailable")
+}
+
+// TestStringLimits tests parsing limits from configuration values
+func TestStringLimits(t *testing.T) {
+	a := mustNew(t, WithMaxReceiveString("4 KiB"), WithMaxSendString("1.5 KB", bytesize.WithFractionalRounding(bytesize.RoundCeil)))
+	if want := (bytesize.Bytes{Lo: 4096}); a.maxReceive != want {
+		t.Errorf("maxReceive = %v, want %v", a.maxReceive.Lo, want.Lo)
+	}
+	if want := (bytesize.Bytes{Lo: 1500}); a.maxSend != want {
+		t.Errorf("maxSend = %v, want %v", a.maxSend.Lo, want.Lo)
+	}
+
+	for _, opt := range []Option{WithMaxReceiveString("lots"), WithMaxSendString("-1 MB")} {
+		if _, err := New(opt); err == nil {
+			t.Errorf("New() with an invalid limit should have errored")
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package bytesize
+
+import (
+	"io"
+	"net/http"
+)
+
+// HTTPMetricsMiddleware returns a handler that serves requests with next
+// and then calls record with the bytes of the request body next read and
+// the bytes of the response body it wrote, such as to feed size
+// histograms. Headers are not counted. The response writer passed to next
+// supports http.ResponseController, so flushing and deadlines keep
+// working.
+func HTTPMetricsMiddleware(next http.Handler, record func(in, out Bytes, r *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := &countingBody{ReadCloser: r.Body}
+		if r.Body != nil {
+			r2 := *r
+			r2.Body = body
+			r = &r2
+		}
+		cw := &countingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(cw, r)
+		record(body.count, cw.count, r)
+	})
+}
+
+// countingBody counts the bytes read from a request body.
+type countingBody struct {
+	io.ReadCloser
+	count Bytes
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.count = Bytes(Uint128(b.count).Add64(uint64(n)))
+	return n, err
+}
+
+// countingResponseWriter counts the bytes written to a response body.
+type countingResponseWriter struct {
+	http.ResponseWriter
+	count Bytes
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.count = Bytes(Uint128(w.count).Add64(uint64(n)))
+	return n, err
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (w *countingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,76 @@
+package bytesize
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestHTTPMetricsMiddleware tests recording the sizes of request and
+// response bodies
+func TestHTTPMetricsMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    io.Reader
+		handler http.HandlerFunc
+		wantIn  Bytes
+		wantOut Bytes
+	}{
+		{
+			name: "echo",
+			body: strings.NewReader("hello world"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(w, r.Body)
+			},
+			wantIn:  Bytes{11, 0},
+			wantOut: Bytes{11, 0},
+		},
+		{
+			name: "partial read",
+			body: strings.NewReader("hello world"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.ReadFull(r.Body, make([]byte, 5))
+				_, _ = io.WriteString(w, "ok")
+				_, _ = io.WriteString(w, "!")
+			},
+			wantIn:  Bytes{5, 0},
+			wantOut: Bytes{3, 0},
+		},
+		{
+			name: "no body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+		},
+		{
+			name: "flush",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, "data")
+				if err := http.NewResponseController(w).Flush(); err != nil {
+					t.Errorf("Flush() error = %v", err)
+				}
+			},
+			wantOut: Bytes{4, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIn, gotOut Bytes
+			var gotPath string
+			h := HTTPMetricsMiddleware(tt.handler, func(in, out Bytes, r *http.Request) {
+				gotIn, gotOut, gotPath = in, out, r.URL.Path
+			})
+
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/upload", tt.body))
+			if gotIn != tt.wantIn || gotOut != tt.wantOut {
+				t.Errorf("record(%v, %v), want (%v, %v)", Uint128(gotIn), Uint128(gotOut), Uint128(tt.wantIn), Uint128(tt.wantOut))
+			}
+			if gotPath != "/upload" {
+				t.Errorf("record() request path = %q, want %q", gotPath, "/upload")
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// This file reproduces the formatting and parsing conventions of other
+// popular size libraries, without depending on them, so that code can move
+// to Bytes one call site at a time while keeping its output and accepted
+// input unchanged:
+//
+//   - github.com/docker/go-units: HumanSize, BytesSize, FromHumanSize and
+//     RAMInBytes
+//   - code.cloudfoundry.org/bytefmt: ByteSize and ToBytes
+//   - github.com/dustin/go-humanize: Bytes, IBytes and ParseBytes
+//
+// Those libraries work in float64 and int64 or uint64. The functions here
+// compute the same results exactly, so they differ only where the
+// originals lose precision: parsed sizes beyond 2^53 bytes are exact rather
+// than rounded to a float64, and sizes beyond the range of the original
+// integer type are returned rather than overflowing.
+
+var (
+	dockerDecimalAbbrs = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB"}
+	dockerBinaryAbbrs  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"}
+)
+
+// DockerHumanSize formats b like docker/go-units HumanSize: SI units, four
+// significant digits and no space, e.g. "1.5GB".
+func DockerHumanSize(b Bytes) string {
+	return dockerSize(b, 1000, dockerDecimalAbbrs)
+}
+
+// DockerBytesSize formats b like docker/go-units BytesSize: IEC units, four
+// significant digits and no space, e.g. "1.5GiB".
+func DockerBytesSize(b Bytes) string {
+	return dockerSize(b, 1024, dockerBinaryAbbrs)
+}
+
+func dockerSize(b Bytes, base int64, abbrs []string) string {
+	value := new(big.Rat).SetInt(Uint128(b).Big())
+	baseRat := big.NewRat(base, 1)
+	i := 0
+	for value.Cmp(baseRat) >= 0 && i < len(abbrs)-1 {
+		value.Quo(value, baseRat)
+		i++
+	}
+	f, _ := value.Float64()
+	return fmt.Sprintf("%.4g%s", f, abbrs[i])
+}
+
+// ParseDockerHumanSize parses s like docker/go-units FromHumanSize, in
+// which every unit is SI: "1k", "1kb" and "1KiB" are all 1000 bytes.
+func ParseDockerHumanSize(s string) (Bytes, error) {
+	return parseDockerSize(s, KB)
+}
+
+// ParseDockerRAMInBytes parses s like docker/go-units RAMInBytes, in which
+// every unit is IEC: "1k", "1kb" and "1KiB" are all 1024 bytes.
+func ParseDockerRAMInBytes(s string) (Bytes, error) {
+	return parseDockerSize(s, KiB)
+}
+
+// parseDockerSize parses a number, an optional space and an optional unit
+// made of a prefix letter, an optional "i" and an optional "b", all case
+// insensitive. kilo is the size of the k prefix.
+func parseDockerSize(s string, kilo Bytes) (Bytes, error) {
+	i := 0
+	for i < len(s) && (s[i] == '.' || ('0' <= s[i] && s[i] <= '9')) {
+		i++
+	}
+	num, suffix := s[:i], strings.TrimPrefix(s[i:], " ")
+	if num == "" || num[0] == '.' || num[len(num)-1] == '.' {
+		return Bytes{}, fmt.Errorf("invalid size: %q", s)
+	}
+
+	multiplier := B
+	if suffix != "" {
+		power := strings.IndexByte("kmgtp", byte(unicode.ToLower(rune(suffix[0]))))
+		if power >= 0 {
+			for range power + 1 {
+				multiplier = Bytes(Uint128(multiplier).MulBytes(kilo))
+			}
+			suffix = suffix[1:]
+		}
+		suffix = strings.TrimPrefix(strings.TrimPrefix(suffix, "i"), "I")
+		suffix = strings.TrimPrefix(strings.TrimPrefix(suffix, "b"), "B")
+		if suffix != "" {
+			return Bytes{}, fmt.Errorf("invalid size: %q", s)
+		}
+	}
+	return truncatedProduct(num, multiplier, s)
+}
+
+var bytefmtUnits = []struct {
+	suffix string
+	factor Bytes
+}{
+	{"E", EiB}, {"P", PiB}, {"T", TiB}, {"G", GiB}, {"M", MiB}, {"K", KiB},
+}
+
+// BytefmtByteSize formats b like cloudfoundry bytefmt ByteSize: IEC units
+// written as single letters, one decimal place that is dropped when zero,
+// and no space, e.g. "1.5K" or "2G".
+func BytefmtByteSize(b Bytes) string {
+	if Uint128(b).IsZero() {
+		return "0B"
+	}
+	n := Uint128(b).Big()
+	for _, u := range bytefmtUnits {
+		if Uint128(b).CmpBytes(u.factor) >= 0 {
+			f, _ := new(big.Rat).SetFrac(n, Uint128(u.factor).Big()).Float64()
+			return strings.TrimSuffix(strconv.FormatFloat(f, 'f', 1, 64), ".0") + u.suffix
+		}
+	}
+	return Uint128(b).String() + "B"
+}
+
+// ParseBytefmt parses s like cloudfoundry bytefmt ToBytes: a number and a
+// required case-insensitive unit of "B" or a prefix letter from K to E,
+// optionally followed by "B" or "iB", all of which are IEC.
+func ParseBytefmt(s string) (Bytes, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	i := strings.IndexFunc(s, unicode.IsLetter)
+	if i == -1 {
+		return Bytes{}, fmt.Errorf("invalid size: %q: missing unit", s)
+	}
+	num, unit := strings.TrimSpace(s[:i]), s[i:]
+	if unit == "B" {
+		return truncatedProduct(num, B, s)
+	}
+	for _, u := range bytefmtUnits {
+		if unit == u.suffix || unit == u.suffix+"B" || unit == u.suffix+"IB" {
+			return truncatedProduct(num, u.factor, s)
+		}
+	}
+	return Bytes{}, fmt.Errorf("invalid size: %q: unknown unit %q", s, unit)
+}
+
+var (
+	humanizeSISizes  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", "RB", "QB"}
+	humanizeIECSizes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB", "RiB", "QiB"}
+)
+
+// HumanizeBytes formats b like go-humanize Bytes: SI units with one
+// decimal place below 10 of a unit and none above, e.g. "83 MB" or
+// "1.5 GB", and sizes below 10 bytes as a plain count, e.g. "9 B".
+func HumanizeBytes(b Bytes) string {
+	return humanizeSize(b, decimalUnitScale[:], humanizeSISizes)
+}
+
+// HumanizeIBytes formats b like go-humanize IBytes, which is HumanizeBytes
+// with IEC units, e.g. "82 MiB".
+func HumanizeIBytes(b Bytes) string {
+	return humanizeSize(b, binaryUnitScale[:], humanizeIECSizes)
+}
+
+func humanizeSize(b Bytes, scale []Bytes, sizes []string) string {
+	if Uint128(b).Cmp64(10) < 0 {
+		return fmt.Sprintf("%d B", Uint128(b).Lo)
+	}
+	i := len(scale) - 1
+	for Uint128(b).CmpBytes(scale[i]) < 0 {
+		i--
+	}
+	// Round to one decimal place, halves up
+	tenths := new(big.Rat).SetFrac(Uint128(b).Big(), Uint128(scale[i]).Big())
+	tenths.Mul(tenths, big.NewRat(10, 1)).Add(tenths, big.NewRat(1, 2))
+	rounded := new(big.Int).Quo(tenths.Num(), tenths.Denom())
+	val, _ := new(big.Rat).SetFrac(rounded, big.NewInt(10)).Float64()
+	if val < 10 {
+		return fmt.Sprintf("%.1f %s", val, sizes[i])
+	}
+	return fmt.Sprintf("%.0f %s", val, sizes[i])
+}
+
+// humanizeSuffixes maps the lowercased unit suffixes go-humanize
+// ParseBytes accepts to their multipliers.
+var humanizeSuffixes = func() map[string]Bytes {
+	m := map[string]Bytes{"": B, "b": B}
+	for i, prefix := range []string{"k", "m", "g", "t", "p", "e", "z", "y", "r", "q"} {
+		m[prefix] = decimalUnitScale[i+1]
+		m[prefix+"b"] = decimalUnitScale[i+1]
+		m[prefix+"i"] = binaryUnitScale[i+1]
+		m[prefix+"ib"] = binaryUnitScale[i+1]
+	}
+	return m
+}()
+
+// ParseHumanize parses s like go-humanize ParseBytes: a number that may
+// contain thousands separators, then an optional case-insensitive unit in
+// which "k" and "kb" are SI and "ki" and "kib" are IEC.
+func ParseHumanize(s string) (Bytes, error) {
+	i := 0
+	for i < len(s) && (s[i] == '.' || s[i] == ',' || ('0' <= s[i] && s[i] <= '9')) {
+		i++
+	}
+	num := strings.ReplaceAll(s[:i], ",", "")
+	suffix := strings.ToLower(strings.TrimSpace(s[i:]))
+	multiplier, ok := humanizeSuffixes[suffix]
+	if !ok {
+		return Bytes{}, fmt.Errorf("unhandled size name: %v", suffix)
+	}
+	return truncatedProduct(num, multiplier, s)
+}
+
+// truncatedProduct returns num × multiplier with any fraction of a byte
+// truncated. input is the whole string, for error messages.
+func truncatedProduct(num string, multiplier Bytes, input string) (Bytes, error) {
+	r, ok := new(big.Rat).SetString(num)
+	if !ok || r.Sign() < 0 || strings.ContainsAny(num, "eE+-/") {
+		return Bytes{}, fmt.Errorf("invalid size: %q", input)
+	}
+	r.Mul(r, new(big.Rat).SetInt(Uint128(multiplier).Big()))
+	u, err := FromBigErr(new(big.Int).Quo(r.Num(), r.Denom()))
+	if err != nil {
+		return Bytes{}, fmt.Errorf("invalid size: %q: value overflows Uint128", input)
+	}
+	return Bytes(u), nil
+}
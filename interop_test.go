@@ -0,0 +1,165 @@
+package bytesize
+
+import "testing"
+
+// TestDockerFormat tests formatting like docker/go-units
+func TestDockerFormat(t *testing.T) {
+	tests := []struct {
+		input Bytes
+		human string
+		bytes string
+	}{
+		{None, "0B", "0B"},
+		{Bytes{999, 0}, "999B", "999B"},
+		{KB, "1kB", "1000B"},
+		{KiB, "1.024kB", "1KiB"},
+		{Bytes{3_420_000_000, 0}, "3.42GB", "3.185GiB"},
+		{Bytes{4_615_632, 0}, "4.616MB", "4.402MiB"},
+		{Bytes(Uint128(YB).Mul64(2000)), "2000YB", "1654YiB"},
+	}
+
+	for _, tt := range tests {
+		if got := DockerHumanSize(tt.input); got != tt.human {
+			t.Errorf("DockerHumanSize(%v) = %q, want %q", Uint128(tt.input), got, tt.human)
+		}
+		if got := DockerBytesSize(tt.input); got != tt.bytes {
+			t.Errorf("DockerBytesSize(%v) = %q, want %q", Uint128(tt.input), got, tt.bytes)
+		}
+	}
+}
+
+// TestDockerParse tests parsing like docker/go-units
+func TestDockerParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		human   Bytes
+		ram     Bytes
+		wantErr bool
+	}{
+		{"32", Bytes{32, 0}, Bytes{32, 0}, false},
+		{"32b", Bytes{32, 0}, Bytes{32, 0}, false},
+		{"32k", Bytes{32_000, 0}, Bytes{32_768, 0}, false},
+		{"32 KiB", Bytes{32_000, 0}, Bytes{32_768, 0}, false},
+		{"32kb", Bytes{32_000, 0}, Bytes{32_768, 0}, false},
+		{"1.5m", Bytes{1_500_000, 0}, Bytes{1_572_864, 0}, false},
+		{"2G", times(GB, 2), times(GiB, 2), false},
+		{"1p", PB, PiB, false},
+		{"", None, None, true},
+		{"k", None, None, true},
+		{"1.2.3k", None, None, true},
+		{"32  k", None, None, true},
+		{"32x", None, None, true},
+		{"32kbb", None, None, true},
+		{"-1k", None, None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			human, err := ParseDockerHumanSize(tt.input)
+			if (err != nil) != tt.wantErr || human != tt.human {
+				t.Errorf("ParseDockerHumanSize(%q) = %v, %v, want %v", tt.input, Uint128(human), err, Uint128(tt.human))
+			}
+			ram, err := ParseDockerRAMInBytes(tt.input)
+			if (err != nil) != tt.wantErr || ram != tt.ram {
+				t.Errorf("ParseDockerRAMInBytes(%q) = %v, %v, want %v", tt.input, Uint128(ram), err, Uint128(tt.ram))
+			}
+		})
+	}
+}
+
+// TestBytefmt tests formatting and parsing like cloudfoundry bytefmt
+func TestBytefmt(t *testing.T) {
+	formatTests := []struct {
+		input Bytes
+		want  string
+	}{
+		{None, "0B"},
+		{Bytes{100, 0}, "100B"},
+		{KiB, "1K"},
+		{Bytes{1536, 0}, "1.5K"},
+		{times(GiB, 2), "2G"},
+		{Bytes{1_000_000_000, 0}, "953.7M"},
+		{times(EiB, 3), "3E"},
+		{QiB, "1099511627776E"},
+	}
+	for _, tt := range formatTests {
+		if got := BytefmtByteSize(tt.input); got != tt.want {
+			t.Errorf("BytefmtByteSize(%v) = %q, want %q", Uint128(tt.input), got, tt.want)
+		}
+	}
+
+	parseTests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"5B", Bytes{5, 0}, false},
+		{"1.5K", Bytes{1536, 0}, false},
+		{"1kb", KiB, false},
+		{" 2 GiB ", times(GiB, 2), false},
+		{"3e", times(EiB, 3), false},
+		{"100", None, true},
+		{"1KIBB", None, true},
+		{"-1K", None, true},
+		{"1X", None, true},
+	}
+	for _, tt := range parseTests {
+		got, err := ParseBytefmt(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseBytefmt(%q) = %v, %v, want %v", tt.input, Uint128(got), err, Uint128(tt.want))
+		}
+	}
+}
+
+// TestHumanize tests formatting and parsing like go-humanize
+func TestHumanize(t *testing.T) {
+	formatTests := []struct {
+		input  Bytes
+		si     string
+		binary string
+	}{
+		{None, "0 B", "0 B"},
+		{Bytes{9, 0}, "9 B", "9 B"},
+		{Bytes{10, 0}, "10 B", "10 B"},
+		{Bytes{1500, 0}, "1.5 kB", "1.5 KiB"},
+		{Bytes{82_854_982, 0}, "83 MB", "79 MiB"},
+		{Bytes{9_950, 0}, "10 kB", "9.7 KiB"},
+		{Bytes{9_949, 0}, "9.9 kB", "9.7 KiB"},
+		{Bytes(Max), "340282367 QB", "268435456 QiB"},
+	}
+	for _, tt := range formatTests {
+		if got := HumanizeBytes(tt.input); got != tt.si {
+			t.Errorf("HumanizeBytes(%v) = %q, want %q", Uint128(tt.input), got, tt.si)
+		}
+		if got := HumanizeIBytes(tt.input); got != tt.binary {
+			t.Errorf("HumanizeIBytes(%v) = %q, want %q", Uint128(tt.input), got, tt.binary)
+		}
+	}
+
+	parseTests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"42", Bytes{42, 0}, false},
+		{"42 MB", times(MB, 42), false},
+		{"42mib", times(MiB, 42), false},
+		{"42 k", Bytes{42_000, 0}, false},
+		{"42Ki", Bytes{43_008, 0}, false},
+		{"1,024 KB", Bytes{1_024_000, 0}, false},
+		{"5.5 GB", Bytes{5_500_000_000, 0}, false},
+		{"42 XB", None, true},
+		{"", None, true},
+	}
+	for _, tt := range parseTests {
+		got, err := ParseHumanize(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseHumanize(%q) = %v, %v, want %v", tt.input, Uint128(got), err, Uint128(tt.want))
+		}
+	}
+}
+
+// times returns b × n.
+func times(b Bytes, n uint64) Bytes {
+	return Bytes(Uint128(b).Mul64(n))
+}
@@ -0,0 +1,84 @@
+package bytesize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// JSONNumber returns b as an exact base-10 JSON number. Unlike a float64 it
+// keeps every digit of sizes beyond 2^53.
+func (b Bytes) JSONNumber() json.Number {
+	return json.Number(Uint128(b).String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Bytes. It
+// accepts a string, which is parsed like Parse; a non-negative integer of
+// any size, which is read exactly rather than through a float64; and the
+// {"Lo": ..., "Hi": ...} object encoding/json produces for Bytes. A null
+// leaves b unchanged.
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return b.Set(s)
+	case len(data) > 0 && data[0] == '{':
+		var u struct{ Lo, Hi uint64 }
+		if err := json.Unmarshal(data, &u); err != nil {
+			return err
+		}
+		*b = Bytes{u.Lo, u.Hi}
+		return nil
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		parsed, err := fromJSONNumber(n)
+		if err != nil {
+			return err
+		}
+		*b = parsed
+		return nil
+	}
+}
+
+// maxJSONExponent bounds the exponent fromJSONNumber accepts. It is well
+// beyond the 39 digits of the largest Bytes value.
+const maxJSONExponent = 1000
+
+// fromJSONNumber converts n, which must be a non-negative integer that
+// fits in 128 bits, to Bytes. Integers written with a fraction or exponent,
+// such as 1.0 or 1e3, are accepted.
+func fromJSONNumber(n json.Number) (Bytes, error) {
+	// Bound the exponent, since big.Rat would otherwise compute 10^exp for
+	// an input as short as 1e999999999
+	if i := strings.IndexAny(n.String(), "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(n.String()[i+1:]); err != nil || exp < -maxJSONExponent || exp > maxJSONExponent {
+			return Bytes{}, fmt.Errorf("invalid number: %s has an exponent out of range", n)
+		}
+	}
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return Bytes{}, fmt.Errorf("invalid number: %s", n)
+	}
+	if !r.IsInt() {
+		return Bytes{}, fmt.Errorf("invalid number: %s is not a whole number of bytes", n)
+	}
+	if r.Sign() < 0 {
+		return Bytes{}, fmt.Errorf("negative value: %s", n)
+	}
+	if r.Num().BitLen() > 128 {
+		return Bytes{}, fmt.Errorf("value overflows Uint128: %s", n)
+	}
+	return Bytes(FromBig(r.Num())), nil
+}
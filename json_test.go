@@ -0,0 +1,86 @@
+package bytesize
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestJSONNumber tests exact JSON number output
+func TestJSONNumber(t *testing.T) {
+	tests := []struct {
+		input Bytes
+		want  json.Number
+	}{
+		{None, "0"},
+		{Bytes{1<<53 + 1, 0}, "9007199254740993"},
+		{Bytes(Max), "340282366920938463463374607431768211455"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.input.JSONNumber(); got != tt.want {
+			t.Errorf("JSONNumber() = %q, want %q", got, tt.want)
+		}
+		data, err := json.Marshal(map[string]json.Number{"size": tt.input.JSONNumber()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"size":` + string(tt.want) + `}`; string(data) != want {
+			t.Errorf("json.Marshal() = %s, want %s", data, want)
+		}
+	}
+}
+
+// TestUnmarshalJSON tests decoding strings, exact integers and the legacy
+// object form
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{`"1.5 KiB"`, Bytes{1536, 0}, false},
+		{`9007199254740993`, Bytes{9007199254740993, 0}, false},
+		{`340282366920938463463374607431768211455`, Bytes(Max), false},
+		{` 1e3 `, KB, false},
+		{`2.0`, Bytes{2, 0}, false},
+		{`{"Lo": 5, "Hi": 1}`, Bytes{5, 1}, false},
+		{`null`, Bytes{7, 0}, false},
+		{`340282366920938463463374607431768211456`, Bytes{}, true},
+		{`-1`, Bytes{}, true},
+		{`1.5`, Bytes{}, true},
+		{`"12 XB"`, Bytes{}, true},
+		{`true`, Bytes{}, true},
+		{`1e999999999`, Bytes{}, true},
+		{`{"Lo": "x"}`, Bytes{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Bytes{7, 0}
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("json.Unmarshal(%s) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestJSONRoundTrip tests that the default encoding of Bytes decodes back
+func TestJSONRoundTrip(t *testing.T) {
+	want := struct{ Size Bytes }{Bytes(Max)}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := want
+	got.Size = None
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+	}
+	if got != want {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", data, Uint128(got.Size), Uint128(want.Size))
+	}
+}
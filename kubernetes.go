@@ -0,0 +1,69 @@
+package bytesize
+
+// kubernetesSuffixes maps the suffixes of Kubernetes resource quantities
+// to their multipliers. Unlike unit names they are case-sensitive, since
+// Kubernetes reads "m" as milli and "M" as mega. The empty suffix is a
+// plain number of bytes.
+var kubernetesSuffixes = map[string]Bytes{
+	"":   B,
+	"k":  KB,
+	"M":  MB,
+	"G":  GB,
+	"T":  TB,
+	"P":  PB,
+	"E":  EB,
+	"Ki": KiB,
+	"Mi": MiB,
+	"Gi": GiB,
+	"Ti": TiB,
+	"Pi": PiB,
+	"Ei": EiB,
+}
+
+// kubernetesScales lists the Kubernetes suffixes of each system in
+// ascending order, starting with the empty suffix.
+var kubernetesScales = [2][]string{
+	{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"},
+	{"", "k", "M", "G", "T", "P", "E"},
+}
+
+// WithKubernetesSuffixes formats byte sizes as Kubernetes resource
+// quantities, such as "1536Mi" or "2Gi": a whole number followed without a
+// space by the largest suffix that divides the size exactly. Binary
+// suffixes are preferred unless a larger decimal suffix divides the size,
+// so 2000 bytes is "2k". The format string, unit system and forced unit
+// options are ignored.
+func WithKubernetesSuffixes() FormatOption {
+	return func(opts *formatOptions) error {
+		opts.kubernetes = true
+		return nil
+	}
+}
+
+// WithKubernetesSuffixParsing makes Parse accept Kubernetes resource
+// quantity suffixes, such as "1536Mi", "2k" or "512M", and plain numbers
+// without a unit, in addition to the usual units. The suffixes are
+// case-sensitive.
+func WithKubernetesSuffixParsing() ParseOption {
+	return func(opts *parseOptions) error {
+		opts.kubernetes = true
+		return nil
+	}
+}
+
+// kubernetesString returns b as a Kubernetes resource quantity.
+func (b Bytes) kubernetesString() string {
+	if Uint128(b).IsZero() {
+		return "0"
+	}
+	best, bestLevel := "", -1
+	for _, scale := range kubernetesScales {
+		for level := len(scale) - 1; level > bestLevel; level-- {
+			if Uint128(b).ModBytes(kubernetesSuffixes[scale[level]]).IsZero() {
+				best, bestLevel = scale[level], level
+				break
+			}
+		}
+	}
+	return Uint128(b).DivBytes(kubernetesSuffixes[best]).String() + best
+}
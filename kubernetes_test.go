@@ -0,0 +1,96 @@
+package bytesize
+
+import "testing"
+
+// TestFormatKubernetesSuffixes tests formatting as Kubernetes quantities
+func TestFormatKubernetesSuffixes(t *testing.T) {
+	tests := []struct {
+		input Bytes
+		want  string
+	}{
+		{None, "0"},
+		{Bytes{1, 0}, "1"},
+		{Bytes{1000, 0}, "1k"},
+		{Bytes{1023, 0}, "1023"},
+		{KiB, "1Ki"},
+		{Bytes{2000, 0}, "2k"},
+		{Bytes{1_024_000, 0}, "1000Ki"},
+		{Bytes(Uint128(MiB).Mul64(1536)), "1536Mi"},
+		{Bytes(Uint128(GiB).Mul64(2)), "2Gi"},
+		{Bytes{512_000_000, 0}, "512M"},
+		{EiB, "1Ei"},
+		{QiB, "1099511627776Ei"},
+		{Bytes(Max), "340282366920938463463374607431768211455"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := tt.input.Format(WithKubernetesSuffixes(), WithLongUnits(true))
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format(%v) = %q, want %q", Uint128(tt.input), got, tt.want)
+			}
+
+			back, err := Parse(got, WithKubernetesSuffixParsing())
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", got, err)
+			}
+			if back != tt.input {
+				t.Errorf("Parse(%q) = %v, want %v", got, Uint128(back), Uint128(tt.input))
+			}
+		})
+	}
+}
+
+// TestParseKubernetesSuffixes tests parsing Kubernetes quantities
+func TestParseKubernetesSuffixes(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"1536Mi", Bytes(Uint128(MiB).Mul64(1536)), false},
+		{"1.5Gi", Bytes(Uint128(MiB).Mul64(1536)), false},
+		{"2k", Bytes{2000, 0}, false},
+		{"100", Bytes{100, 0}, false},
+		{"1 MB", MB, false},
+		{"1 KiB", KiB, false},
+		{"1K", Bytes{}, true},
+		{"1m", Bytes{}, true},
+		{"1mi", Bytes{}, true},
+		{"Mi", Bytes{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, WithKubernetesSuffixParsing())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+
+	if _, err := Parse("1536Mi"); err == nil {
+		t.Error("Parse(\"1536Mi\") without WithKubernetesSuffixParsing returned no error")
+	}
+}
+
+// TestFormatterKubernetesSuffixes tests that Formatter and FormatBatch
+// honor WithKubernetesSuffixes
+func TestFormatterKubernetesSuffixes(t *testing.T) {
+	f, err := NewFormatter(WithKubernetesSuffixes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Format(GiB); got != "1Gi" {
+		t.Errorf("Formatter.Format(GiB) = %q, want %q", got, "1Gi")
+	}
+	if got := string(f.AppendFormat(nil, GB)); got != "1G" {
+		t.Errorf("Formatter.AppendFormat(GB) = %q, want %q", got, "1G")
+	}
+}
@@ -0,0 +1,98 @@
+package bytesize
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Ledger records how many bytes were transferred when, such as the bytes
+// each request to a metered API sent, and totals them over time ranges. It
+// keeps the most recent samples up to its capacity in a ring buffer,
+// dropping the oldest as new ones are recorded. It is safe for concurrent
+// use. Use NewLedger to choose the capacity; the zero value is a Ledger
+// with a capacity of 1024 samples.
+//
+// Samples are kept individually rather than summed into time buckets, so
+// totals are exact for any range, at the cost of memory and time
+// proportional to the capacity: each sample takes 40 bytes, about 40 KiB
+// for the default capacity, and TotalBetween scans every sample kept. For
+// a Ledger that must cover a long period at a high request rate, record
+// pre-aggregated totals, such as one sample per minute, instead.
+type Ledger struct {
+	mu      sync.Mutex
+	samples []ledgerSample
+	next    int // where the next sample goes once samples is full
+}
+
+// defaultLedgerCapacity is the capacity of a zero Ledger.
+const defaultLedgerCapacity = 1024
+
+// ledgerSample is a transfer recorded in a Ledger.
+type ledgerSample struct {
+	at time.Time
+	n  Bytes
+}
+
+// NewLedger returns a Ledger keeping up to capacity samples. It panics if
+// capacity is not positive.
+func NewLedger(capacity int) *Ledger {
+	if capacity <= 0 {
+		panic("NewLedger: invalid capacity: " + strconv.Itoa(capacity))
+	}
+	return &Ledger{samples: make([]ledgerSample, 0, capacity)}
+}
+
+// Record records that n bytes were transferred at t, dropping the oldest
+// sample if the Ledger is full. Samples may be recorded out of order; the
+// one dropped is always the one recorded longest ago.
+func (l *Ledger) Record(t time.Time, n Bytes) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.samples == nil {
+		l.samples = make([]ledgerSample, 0, defaultLedgerCapacity)
+	}
+	s := ledgerSample{at: t, n: n}
+	if len(l.samples) < cap(l.samples) {
+		l.samples = append(l.samples, s)
+		return
+	}
+	l.samples[l.next] = s
+	l.next = (l.next + 1) % len(l.samples)
+}
+
+// TotalBetween returns the total of the samples recorded at or after t1 and
+// before t2, saturating at Max. Samples already dropped are not counted.
+func (l *Ledger) TotalBetween(t1, t2 time.Time) Bytes {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var total Uint128
+	for _, s := range l.samples {
+		if s.at.Before(t1) || !s.at.Before(t2) {
+			continue
+		}
+		sum, err := total.AddErr(Uint128(s.n))
+		if err != nil {
+			return Bytes(Max)
+		}
+		total = sum
+	}
+	return Bytes(total)
+}
+
+// RateBetween returns the average rate of transfer from t1 to t2, the
+// TotalBetween them per the time between them. The rate is exact rather
+// than rounded to bytes per second, and PerDay or PerMonth scale it up. Per
+// is not positive if t2 is not after t1.
+func (l *Ledger) RateBetween(t1, t2 time.Time) Rate {
+	return Rate{Amount: l.TotalBetween(t1, t2), Per: t2.Sub(t1)}
+}
+
+// Len returns the number of samples kept, at most the capacity.
+func (l *Ledger) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.samples)
+}
@@ -0,0 +1,99 @@
+package bytesize
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLedgerTotalBetween tests totalling samples over time ranges
+func TestLedgerTotalBetween(t *testing.T) {
+	t0 := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLedger(10)
+	for i := range 5 {
+		l.Record(t0.Add(time.Duration(i)*time.Minute), times(MB, uint64(i+1)))
+	}
+
+	tests := []struct {
+		name   string
+		t1, t2 time.Time
+		want   Bytes
+	}{
+		{"all", t0, t0.Add(time.Hour), times(MB, 15)},
+		{"start inclusive end exclusive", t0.Add(time.Minute), t0.Add(3 * time.Minute), times(MB, 5)},
+		{"empty range", t0.Add(time.Hour), t0.Add(2 * time.Hour), None},
+		{"reversed", t0.Add(time.Hour), t0, None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.TotalBetween(tt.t1, tt.t2); got != tt.want {
+				t.Errorf("TotalBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLedgerRetention tests that a full Ledger drops the oldest samples
+func TestLedgerRetention(t *testing.T) {
+	t0 := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLedger(3)
+	for i := range 5 {
+		l.Record(t0.Add(time.Duration(i)*time.Second), times(KB, uint64(i+1)))
+	}
+	if got := l.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	if got, want := l.TotalBetween(t0, t0.Add(time.Minute)), times(KB, 12); got != want {
+		t.Errorf("TotalBetween() = %v, want %v", got, want)
+	}
+
+	// A late sample for an early time still replaces the oldest recorded
+	l.Record(t0, times(KB, 100))
+	if got, want := l.TotalBetween(t0, t0.Add(time.Minute)), times(KB, 109); got != want {
+		t.Errorf("TotalBetween() after late sample = %v, want %v", got, want)
+	}
+}
+
+// TestLedgerRateBetween tests the average rate over a time range
+func TestLedgerRateBetween(t *testing.T) {
+	t0 := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLedger(4)
+	l.Record(t0, times(MB, 30))
+	l.Record(t0.Add(30*time.Second), times(MB, 30))
+	l.Record(t0, Bytes(Max))
+
+	got := l.RateBetween(t0.Add(time.Second), t0.Add(time.Minute))
+	if want := (Rate{times(MB, 30), 59 * time.Second}); got != want {
+		t.Errorf("RateBetween() = %v, want %v", got, want)
+	}
+	if got := l.TotalBetween(t0, t0.Add(time.Minute)); got != Bytes(Max) {
+		t.Errorf("TotalBetween() = %v, want Max", got)
+	}
+}
+
+// TestLedgerZero tests that a zero Ledger keeps the default number of
+// samples
+func TestLedgerZero(t *testing.T) {
+	t0 := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var l Ledger
+	for i := range defaultLedgerCapacity + 1 {
+		l.Record(t0.Add(time.Duration(i)*time.Second), KB)
+	}
+	if got := l.Len(); got != defaultLedgerCapacity {
+		t.Errorf("Len() = %d, want %d", got, defaultLedgerCapacity)
+	}
+	if got, want := l.TotalBetween(t0, t0.Add(time.Hour)), times(KB, defaultLedgerCapacity); got != want {
+		t.Errorf("TotalBetween() = %v, want %v", got, want)
+	}
+}
+
+// TestNewLedgerPanics tests that NewLedger rejects a capacity below one
+func TestNewLedgerPanics(t *testing.T) {
+	defer func() {
+		const want = "NewLedger: invalid capacity: 0"
+		if r := recover(); r != want {
+			t.Errorf("NewLedger(0) panicked with %v, want %q", r, want)
+		}
+	}()
+	NewLedger(0)
+}
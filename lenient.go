@@ -0,0 +1,83 @@
+package bytesize
+
+import "strings"
+
+// WithLenientUnits makes Parse accept the alternate spellings of unit names
+// that people type, in addition to the usual units. The words of a unit may
+// be separated by whitespace, hyphens or underscores, as in "gibi byte",
+// "kibi-bytes" or "mega_bytes", and a short prefix may be followed by
+// "byte" or "bytes", as in "GiBytes", "KBytes" or "kByte". The colloquial
+// "kilo", "meg", "gig" and "tera" and their plurals are the decimal units,
+// as in "500 megs" or "2 gigs". Common look-alikes, the fullwidth forms and
+// letters drawn like Latin ones, such as "１０ ＭＢ", "ᴋʙ" or a Greek capital
+// kappa, are folded to their ASCII equivalents, so offsets in errors refer
+// to the input after that folding. This is not full Unicode NFKC
+// normalization.
+func WithLenientUnits() ParseOption {
+	return func(opts *parseOptions) error {
+		opts.lenientUnits = true
+		return nil
+	}
+}
+
+// lenientPrefixes maps the lowercased short prefixes to the decimal and
+// binary units they start.
+var lenientPrefixes = map[string][2]Bytes{
+	"k": {KB, KiB},
+	"m": {MB, MiB},
+	"g": {GB, GiB},
+	"t": {TB, TiB},
+	"p": {PB, PiB},
+	"e": {EB, EiB},
+	"z": {ZB, ZiB},
+	"y": {YB, YiB},
+	"r": {RB, RiB},
+	"q": {QB, QiB},
+}
+
+// lenientAliases maps the colloquial unit names WithLenientUnits accepts,
+// lowercased, to their units.
+var lenientAliases = map[string]Bytes{
+	"kilo":  KB,
+	"kilos": KB,
+	"meg":   MB,
+	"megs":  MB,
+	"gig":   GB,
+	"gigs":  GB,
+	"tera":  TB,
+	"teras": TB,
+}
+
+// lenientSeparators removes the separators WithLenientUnits allows between
+// the words of a unit.
+var lenientSeparators = strings.NewReplacer("-", "", "_", "", " ", "", "\t", "")
+
+// lookupLenientUnit returns the multiplier for a unit under the spellings
+// WithLenientUnits accepts.
+func lookupLenientUnit(unitStr string) (Bytes, bool) {
+	unit := strings.ToLower(lenientSeparators.Replace(strings.TrimSpace(unitStr)))
+	if multiplier, ok := lookupUnit(unit); ok {
+		return multiplier, true
+	}
+	if multiplier, ok := lenientAliases[unit]; ok {
+		return multiplier, true
+	}
+
+	// A short prefix followed by "byte" or "bytes", as in "gibytes"
+	rest, ok := strings.CutSuffix(unit, "bytes")
+	if !ok {
+		rest, ok = strings.CutSuffix(unit, "byte")
+	}
+	if !ok || rest == "" {
+		return Bytes{}, false
+	}
+	binary := 0
+	if prefix, ok := strings.CutSuffix(rest, "i"); ok {
+		rest, binary = prefix, 1
+	}
+	units, ok := lenientPrefixes[rest]
+	if !ok {
+		return Bytes{}, false
+	}
+	return units[binary], true
+}
@@ -0,0 +1,61 @@
+package bytesize
+
+import "testing"
+
+// TestParseLenientUnits tests parsing alternate spellings of unit names
+func TestParseLenientUnits(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"1 gibibyte", GiB, false},
+		{"1 gibi byte", GiB, false},
+		{"2 gibi  bytes", times(GiB, 2), false},
+		{"1 GiBytes", GiB, false},
+		{"1 kibi-bytes", KiB, false},
+		{"1 mega_bytes", MB, false},
+		{"1 KBytes", KB, false},
+		{"1 Mbyte", MB, false},
+		{"1.5 Ti Bytes", times(GiB, 1536), false},
+		{"3 kilo - bytes ", Bytes{3000, 0}, false},
+		{"1 kbyte", KB, false},
+		{"1 kByte", KB, false},
+		{"2 gigs", times(GB, 2), false},
+		{"1 Gig", GB, false},
+		{"500 megs", times(MB, 500), false},
+		{"4 kilos", times(KB, 4), false},
+		{"3 tera", times(TB, 3), false},
+		{"1 MB", MB, false},
+		{"1 bytes", B, false},
+		{"1 gigabits", None, true},
+		{"1 gigsbytes", None, true},
+		{"1 xbytes", None, true},
+		{"1 ibytes", None, true},
+		{"1 gibi byte 2", None, true},
+		{"1 kibi+bytes", None, true},
+		{"-1 gibibyte", None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, WithLenientUnits())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestParseLenientUnitsOptIn tests that alternate spellings are rejected
+// without WithLenientUnits
+func TestParseLenientUnitsOptIn(t *testing.T) {
+	for _, input := range []string{"1 gibi byte", "1 GiBytes", "1 kibi-bytes", "1 KBytes", "2 gigs", "500 megs", "1 kByte"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) without WithLenientUnits returned no error", input)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package bytesize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Warning describes something questionable about a size string, such as an
+// ambiguous unit, that does not stop it from parsing.
+type Warning struct {
+	// Offset is the byte offset in the input of the text warned about.
+	Offset int
+	// Msg describes the problem.
+	Msg string
+}
+
+// String returns the warning as "offset N: message".
+func (w Warning) String() string {
+	return fmt.Sprintf("offset %d: %s", w.Offset, w.Msg)
+}
+
+// smallSIPrefixes maps the lowercase letters that are SI prefixes for
+// fractions, but which Parse reads as the uppercase prefixes for multiples,
+// to the names of the fractions.
+var smallSIPrefixes = map[byte]string{
+	'm': "milli",
+	'p': "pico",
+	'z': "zepto",
+	'y': "yocto",
+	'r': "ronto",
+	'q': "quecto",
+}
+
+// LintSizeString reports ambiguities in s that Parse accepts without
+// complaint, for config linters and review tools:
+//
+//   - a lowercase prefix that is an SI prefix for a fraction, as in "5 mB",
+//     which Parse reads as megabytes but SI reads as millibytes
+//   - a lowercase "b", as in "10 Mb", which usually means bits
+//   - a fraction of a byte, as in "1.5 B", which Parse truncates
+//
+// If s does not parse, the only warning is the parse error. It returns nil
+// if there is nothing to report.
+func LintSizeString(s string) []Warning {
+	p, err := ParseDetailed(s)
+	if err != nil {
+		offset := 0
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset = syntaxErr.Offset
+		}
+		return []Warning{{Offset: offset, Msg: err.Error()}}
+	}
+
+	var warnings []Warning
+	unitOffset := strings.LastIndex(s, p.Unit)
+	// Only short units are ambiguous; long names are spelled out
+	if u := p.Unit; len(u) <= 3 && u != "" {
+		last := len(u) - 1
+		name, smallPrefix := smallSIPrefixes[u[0]]
+		smallPrefix = smallPrefix && last > 0
+		fixed := []byte(u)
+		if smallPrefix {
+			fixed[0] -= 'a' - 'A'
+		}
+		if u[last] == 'b' {
+			fixed[last] = 'B'
+		}
+		if len(u) == 3 && (u[1] == 'i' || u[1] == 'I') {
+			// IEC prefixes are always an uppercase letter and "i"
+			fixed[0] = strings.ToUpper(u[:1])[0]
+			fixed[1] = 'i'
+		}
+
+		if smallPrefix {
+			warnings = append(warnings, Warning{unitOffset, fmt.Sprintf(
+				"lowercase %q is the SI prefix %s; use %q", u[:1], name, fixed)})
+		}
+		if u[last] == 'b' {
+			warnings = append(warnings, Warning{unitOffset + last, fmt.Sprintf(
+				"lowercase \"b\" usually means bits; use %q for bytes", fixed)})
+		}
+	}
+	if !p.Exact {
+		warnings = append(warnings, Warning{0, fmt.Sprintf(
+			"%s is not a whole number of bytes; the fraction is truncated to %s B", p, Uint128(p.Bytes))})
+	}
+	return warnings
+}
@@ -0,0 +1,56 @@
+package bytesize
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestLintSizeString tests reporting ambiguities in size strings
+func TestLintSizeString(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []Warning
+	}{
+		{"0.000001 GB", nil},
+		{"1 MB", nil},
+		{"1 megabyte", nil},
+		{"1 kB", nil},
+		{"5 mB", []Warning{{2, `lowercase "m" is the SI prefix milli; use "MB"`}}},
+		{"10 Mb", []Warning{{4, `lowercase "b" usually means bits; use "MB" for bytes`}}},
+		{"1 b", []Warning{{2, `lowercase "b" usually means bits; use "B" for bytes`}}},
+		{"  2mb ", []Warning{
+			{3, `lowercase "m" is the SI prefix milli; use "MB"`},
+			{4, `lowercase "b" usually means bits; use "MB" for bytes`},
+		}},
+		{"1 pib", []Warning{
+			{2, `lowercase "p" is the SI prefix pico; use "PiB"`},
+			{4, `lowercase "b" usually means bits; use "PiB" for bytes`},
+		}},
+		{"1 KB", nil},
+		{"1 kib", []Warning{{4, `lowercase "b" usually means bits; use "KiB" for bytes`}}},
+		{"1 KIb", []Warning{{4, `lowercase "b" usually means bits; use "KiB" for bytes`}}},
+		{"1 mib", []Warning{
+			{2, `lowercase "m" is the SI prefix milli; use "MiB"`},
+			{4, `lowercase "b" usually means bits; use "MiB" for bytes`},
+		}},
+		{"1.5 B", []Warning{{0, "1.5 B is not a whole number of bytes; the fraction is truncated to 1 B"}}},
+		{"1 XB", []Warning{{0, "unknown unit: xb"}}},
+		{"1 2 MB", []Warning{{2, `error parsing number and unit: unexpected '2' at offset 2 in "1 2 MB"`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := LintSizeString(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintSizeString(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWarningString tests formatting a Warning
+func TestWarningString(t *testing.T) {
+	w := Warning{Offset: 3, Msg: "problem"}
+	if got, want := w.String(), "offset 3: problem"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
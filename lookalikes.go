@@ -0,0 +1,87 @@
+package bytesize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// lookalikes maps characters that look like the letters, digits and signs
+// of a size to the ASCII characters they stand for, so that input pasted
+// from documents and chat, such as "ＭＢ", "ᴋʙ" or a Greek "Κ", parses
+// under WithLenientUnits. This is common look-alike folding, not Unicode
+// normalization: it holds the few NFKC compatibility mappings seen in
+// sizes, together with the small capitals and the Greek and Cyrillic
+// letters that are drawn like Latin ones. The fullwidth forms U+FF01 to
+// U+FF5E are folded by foldLookalikes directly.
+var lookalikes = map[rune]rune{
+	// NFKC compatibility mappings seen in sizes
+	'\u00b5': '\u03bc', // MICRO SIGN
+	'\u212a': 'K',      // KELVIN SIGN
+	'\u2024': '.',      // ONE DOT LEADER
+	'\u2212': '-',      // MINUS SIGN
+	'\u3000': ' ',      // IDEOGRAPHIC SPACE
+
+	// Small capitals
+	'\u0299': 'b', // LATIN LETTER SMALL CAPITAL B
+	'\u1d07': 'e', // LATIN LETTER SMALL CAPITAL E
+	'\u0262': 'g', // LATIN LETTER SMALL CAPITAL G
+	'\u026a': 'i', // LATIN LETTER SMALL CAPITAL I
+	'\u1d0b': 'k', // LATIN LETTER SMALL CAPITAL K
+	'\u1d0d': 'm', // LATIN LETTER SMALL CAPITAL M
+	'\u1d18': 'p', // LATIN LETTER SMALL CAPITAL P
+	'\ua7af': 'q', // LATIN LETTER SMALL CAPITAL Q
+	'\u0280': 'r', // LATIN LETTER SMALL CAPITAL R
+	'\u1d1b': 't', // LATIN LETTER SMALL CAPITAL T
+	'\u028f': 'y', // LATIN LETTER SMALL CAPITAL Y
+	'\u1d22': 'z', // LATIN LETTER SMALL CAPITAL Z
+
+	// Greek letters
+	'\u0392': 'B', // GREEK CAPITAL LETTER BETA
+	'\u0395': 'E', // GREEK CAPITAL LETTER EPSILON
+	'\u0396': 'Z', // GREEK CAPITAL LETTER ZETA
+	'\u0399': 'I', // GREEK CAPITAL LETTER IOTA
+	'\u039a': 'K', // GREEK CAPITAL LETTER KAPPA
+	'\u039c': 'M', // GREEK CAPITAL LETTER MU
+	'\u03a1': 'P', // GREEK CAPITAL LETTER RHO
+	'\u03a4': 'T', // GREEK CAPITAL LETTER TAU
+	'\u03a5': 'Y', // GREEK CAPITAL LETTER UPSILON
+	'\u03ba': 'k', // GREEK SMALL LETTER KAPPA
+
+	// Cyrillic letters
+	'\u0406': 'I', // CYRILLIC CAPITAL LETTER BYELORUSSIAN-UKRAINIAN I
+	'\u0412': 'B', // CYRILLIC CAPITAL LETTER VE
+	'\u0415': 'E', // CYRILLIC CAPITAL LETTER IE
+	'\u041a': 'K', // CYRILLIC CAPITAL LETTER KA
+	'\u041c': 'M', // CYRILLIC CAPITAL LETTER EM
+	'\u0420': 'P', // CYRILLIC CAPITAL LETTER ER
+	'\u0422': 'T', // CYRILLIC CAPITAL LETTER TE
+	'\u0435': 'e', // CYRILLIC SMALL LETTER IE
+	'\u0456': 'i', // CYRILLIC SMALL LETTER BYELORUSSIAN-UKRAINIAN I
+	'\u043a': 'k', // CYRILLIC SMALL LETTER KA
+	'\u0440': 'p', // CYRILLIC SMALL LETTER ER
+}
+
+// foldLookalikes returns s with the characters in lookalikes and the
+// fullwidth forms replaced by their ASCII equivalents. Other characters,
+// including other compatibility characters, are left as they are. A micro
+// sign becomes a Greek mu, as in NFKC, and is still not a valid unit
+// prefix: there is no such thing as a microbyte.
+func foldLookalikes(s string) string {
+	// Most input is ASCII, which has nothing to replace
+	i := 0
+	for i < len(s) && s[i] < utf8.RuneSelf {
+		i++
+	}
+	if i == len(s) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if '\uff01' <= r && r <= '\uff5e' {
+			return r - 0xfee0
+		}
+		if m, ok := lookalikes[r]; ok {
+			return m
+		}
+		return r
+	}, s)
+}
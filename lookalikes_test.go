@@ -0,0 +1,76 @@
+package bytesize
+
+import "testing"
+
+// TestParseLookalikes tests parsing sizes written with fullwidth forms and
+// lookalike letters
+func TestParseLookalikes(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"1 ＭＢ", MB, false},
+		{"１０ ＭＢ", times(MB, 10), false},
+		{"1．5 KiB", Bytes{1536, 0}, false},
+		{"1 ᴋʙ", KB, false},
+		{"1 ΚB", KB, false},
+		{"1 ΚіВ", KiB, false},
+		{"1 \u212aiB", KiB, false},
+		{"1\u3000GB", GB, false},
+		{"2 ɢɪʙ", times(GiB, 2), false},
+		{"1 ᴍegaʙytes", MB, false},
+		{"1 µB", None, true},
+		{"\u22121 MB", None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, WithLenientUnits())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestParseLookalikesOptIn tests that lookalikes are rejected without
+// WithLenientUnits
+func TestParseLookalikesOptIn(t *testing.T) {
+	for _, input := range []string{"1 ＭＢ", "1 ΚB", "１ MB"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) without WithLenientUnits returned no error", input)
+		}
+	}
+}
+
+// TestFoldLookalikes tests folding lookalikes to ASCII
+func TestFoldLookalikes(t *testing.T) {
+	tests := map[string]string{
+		"10 MB":        "10 MB",
+		"１０Ｍ":          "10M",
+		"\u00b5":       "\u03bc",
+		"\u2212\u2024": "-.",
+		"é":            "é",
+	}
+	for input, want := range tests {
+		if got := foldLookalikes(input); got != want {
+			t.Errorf("foldLookalikes(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// TestParseDetailedLookalikes tests that ParseDetailed reports the unit
+// after lookalikes are replaced
+func TestParseDetailedLookalikes(t *testing.T) {
+	p, err := ParseDetailed("２ ＭｉＢ", WithLenientUnits())
+	if err != nil {
+		t.Fatalf("ParseDetailed() error = %v", err)
+	}
+	if p.Number != "2" || p.Unit != "MiB" || p.Bytes != times(MiB, 2) {
+		t.Errorf("ParseDetailed() = %+v, want 2 MiB", p)
+	}
+}
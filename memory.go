@@ -0,0 +1,35 @@
+package bytesize
+
+import (
+	"math"
+	"os"
+)
+
+// Memory sizes for allocator and mmap code. These are variables, like the
+// units, because Bytes is a struct.
+var (
+	// CacheLine is the usual CPU cache line size, 64 bytes on amd64 and
+	// most arm64 cores. Some cores, such as Apple's, use 128 bytes.
+	CacheLine = Bytes{64, 0}
+	// HugePage2M is the size of an x86-64 2 MiB huge page.
+	HugePage2M = Bytes{2 << 20, 0}
+	// HugePage1G is the size of an x86-64 1 GiB huge page.
+	HugePage1G = Bytes{1 << 30, 0}
+)
+
+// PageSize returns the memory page size of the operating system, as
+// reported by os.Getpagesize.
+func PageSize() Bytes {
+	return Bytes{uint64(os.Getpagesize()), 0}
+}
+
+// PagesFor returns the number of pages of PageSize needed to hold b, i.e.
+// b divided by the page size and rounded up. It saturates at
+// math.MaxUint64.
+func PagesFor(b Bytes) uint64 {
+	pages := ceilQuo(Uint128(b), Uint128(PageSize()))
+	if pages.Hi != 0 {
+		return math.MaxUint64
+	}
+	return pages.Lo
+}
@@ -0,0 +1,48 @@
+package bytesize
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+// TestMemorySizes tests the memory size variables
+func TestMemorySizes(t *testing.T) {
+	if HugePage2M != times(MiB, 2) {
+		t.Errorf("HugePage2M = %v, want 2 MiB", Uint128(HugePage2M))
+	}
+	if HugePage1G != GiB {
+		t.Errorf("HugePage1G = %v, want 1 GiB", Uint128(HugePage1G))
+	}
+	if CacheLine != (Bytes{64, 0}) {
+		t.Errorf("CacheLine = %v, want 64 bytes", Uint128(CacheLine))
+	}
+	if got, want := PageSize(), (Bytes{uint64(os.Getpagesize()), 0}); got != want {
+		t.Errorf("PageSize() = %v, want %v", Uint128(got), Uint128(want))
+	}
+}
+
+// TestPagesFor tests counting the pages needed to hold a size
+func TestPagesFor(t *testing.T) {
+	page := PageSize().Lo
+	tests := []struct {
+		name string
+		b    Bytes
+		want uint64
+	}{
+		{"zero", None, 0},
+		{"one byte", B, 1},
+		{"one page", PageSize(), 1},
+		{"one page and a byte", Bytes{page + 1, 0}, 2},
+		{"huge page", HugePage2M, (2 << 20) / page},
+		{"saturated", Bytes(Max), math.MaxUint64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PagesFor(tt.b); got != tt.want {
+				t.Errorf("PagesFor(%v) = %d, want %d", Uint128(tt.b), got, tt.want)
+			}
+		})
+	}
+}
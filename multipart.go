@@ -0,0 +1,45 @@
+package bytesize
+
+import "fmt"
+
+// PartSize returns the part size and part count for uploading total bytes
+// in parts, as in S3 or GCS multipart uploads, where there may be at most
+// maxParts parts and each part but the last must be between minPart and
+// maxPart. It chooses the smallest valid part size, so that a failed part
+// costs the least to retry. An empty upload is a single empty part.
+//
+// For S3, maxParts is 10000, minPart 5 MiB and maxPart 5 GiB. It returns an
+// error if the constraints are invalid or total does not fit in maxParts
+// parts of maxPart bytes.
+func PartSize(total Bytes, maxParts int, minPart, maxPart Bytes) (Bytes, int, error) {
+	switch {
+	case maxParts < 1:
+		return Bytes{}, 0, fmt.Errorf("invalid part count limit: %d", maxParts)
+	case Uint128(minPart).IsZero():
+		return Bytes{}, 0, fmt.Errorf("invalid minimum part size: zero")
+	case Uint128(minPart).CmpBytes(maxPart) > 0:
+		return Bytes{}, 0, fmt.Errorf("minimum part size %s is greater than the maximum of %s", minPart, maxPart)
+	}
+	if Uint128(total).IsZero() {
+		return minPart, 1, nil
+	}
+
+	part := ceilQuo(Uint128(total), From64(uint64(maxParts)))
+	if part.CmpBytes(minPart) < 0 {
+		part = Uint128(minPart)
+	}
+	if part.CmpBytes(maxPart) > 0 {
+		return Bytes{}, 0, fmt.Errorf("%s does not fit in %d parts of at most %s", total, maxParts, maxPart)
+	}
+	// The count is at most maxParts, so it fits in an int
+	return Bytes(part), int(ceilQuo(Uint128(total), part).Lo), nil
+}
+
+// ceilQuo returns n/d rounded up.
+func ceilQuo(n, d Uint128) Uint128 {
+	q, r := n.QuoRem(d)
+	if !r.IsZero() {
+		q = q.AddWrap64(1)
+	}
+	return q
+}
@@ -0,0 +1,42 @@
+package bytesize
+
+import "testing"
+
+// TestPartSize tests choosing multipart upload part sizes
+func TestPartSize(t *testing.T) {
+	s3Min, s3Max := times(MiB, 5), times(GiB, 5)
+	tests := []struct {
+		name      string
+		total     Bytes
+		maxParts  int
+		minPart   Bytes
+		maxPart   Bytes
+		wantPart  Bytes
+		wantCount int
+		wantErr   bool
+	}{
+		{"empty", None, 10000, s3Min, s3Max, s3Min, 1, false},
+		{"smaller than a part", MiB, 10000, s3Min, s3Max, s3Min, 1, false},
+		{"minimum parts", GiB, 10000, s3Min, s3Max, s3Min, 205, false},
+		{"exact minimum", times(MiB, 50_000), 10000, s3Min, s3Max, s3Min, 10000, false},
+		{"larger parts", times(GiB, 100), 10000, s3Min, s3Max, Bytes{10737419, 0}, 10000, false},
+		{"rounded count", Bytes{101, 0}, 10, B, KB, Bytes{11, 0}, 10, false},
+		{"largest", times(GiB, 50_000), 10000, s3Min, s3Max, s3Max, 10000, false},
+		{"too large", times(GiB, 50_001), 10000, s3Min, s3Max, None, 0, true},
+		{"no parts", GiB, 0, s3Min, s3Max, None, 0, true},
+		{"zero minimum", GiB, 10000, None, s3Max, None, 0, true},
+		{"minimum over maximum", GiB, 10000, s3Max, s3Min, None, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part, count, err := PartSize(tt.total, tt.maxParts, tt.minPart, tt.maxPart)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PartSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if part != tt.wantPart || count != tt.wantCount {
+				t.Errorf("PartSize() = %v, %d, want %v, %d", Uint128(part), count, Uint128(tt.wantPart), tt.wantCount)
+			}
+		})
+	}
+}
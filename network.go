@@ -0,0 +1,54 @@
+package bytesize
+
+import (
+	"math/big"
+	"time"
+)
+
+// minSocketBuffer is the smallest buffer SuggestSocketBuffer suggests,
+// the usual default TCP window.
+var minSocketBuffer = Bytes{64 << 10, 0}
+
+// BDP returns the bandwidth-delay product of a link with the rate and the
+// round-trip time rtt: the bytes that must be in flight to keep the link
+// busy. It is rounded up to a whole byte and saturates at Max. It is zero
+// if rtt or the rate's Per is not positive.
+func BDP(rate Rate, rtt time.Duration) Bytes {
+	if rate.Per <= 0 || rtt <= 0 {
+		return None
+	}
+	n := new(big.Int).Mul(Uint128(rate.Amount).Big(), big.NewInt(int64(rtt)))
+	q, r := n.QuoRem(n, big.NewInt(int64(rate.Per)), new(big.Int))
+	if r.Sign() > 0 {
+		q.Add(q, bigOne)
+	}
+	u, err := FromBigErr(q)
+	if err != nil {
+		return Bytes(Max)
+	}
+	return Bytes(u)
+}
+
+// SuggestSocketBuffer returns a socket buffer size, for SO_SNDBUF and
+// SO_RCVBUF or the TCP autotuning limits, for a link with the rate and the
+// round-trip time rtt. It is twice the BDP, since the kernel keeps part of
+// the buffer for its own bookkeeping, rounded up to a power of two and at
+// least 64 KiB. It saturates at Max.
+func SuggestSocketBuffer(rate Rate, rtt time.Duration) Bytes {
+	bdp := Uint128(BDP(rate, rtt))
+	buf, err := bdp.Mul64Err(2)
+	if err != nil {
+		return Bytes(Max)
+	}
+	if buf.CmpBytes(minSocketBuffer) < 0 {
+		return minSocketBuffer
+	}
+	// Round up to a power of two unless buf already is one
+	if !buf.And(buf.Sub64(1)).IsZero() {
+		if buf.Len() == 128 {
+			return Bytes(Max)
+		}
+		buf = From64(1).Lsh(uint(buf.Len()))
+	}
+	return Bytes(buf)
+}
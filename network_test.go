@@ -0,0 +1,60 @@
+package bytesize
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBDP tests bandwidth-delay products
+func TestBDP(t *testing.T) {
+	gbit := PerSecond(Bytes{125_000_000, 0})
+	tests := []struct {
+		name string
+		rate Rate
+		rtt  time.Duration
+		want Bytes
+	}{
+		{"1 Gbit/s at 20ms", gbit, 20 * time.Millisecond, Bytes{2_500_000, 0}},
+		{"1 Gbit/s at 100ms", gbit, 100 * time.Millisecond, Bytes{12_500_000, 0}},
+		{"per minute", Rate{Bytes{60, 0}, time.Minute}, time.Second, B},
+		{"rounded up", PerSecond(Bytes{3, 0}), 500 * time.Millisecond, Bytes{2, 0}},
+		{"zero rtt", gbit, 0, None},
+		{"negative rtt", gbit, -time.Second, None},
+		{"zero period", Rate{GB, 0}, time.Second, None},
+		{"saturated", PerSecond(Bytes(Max)), time.Hour, Bytes(Max)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BDP(tt.rate, tt.rtt); got != tt.want {
+				t.Errorf("BDP(%v, %v) = %v, want %v", tt.rate, tt.rtt, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestSuggestSocketBuffer tests suggested socket buffer sizes
+func TestSuggestSocketBuffer(t *testing.T) {
+	tests := []struct {
+		name string
+		rate Rate
+		rtt  time.Duration
+		want Bytes
+	}{
+		{"1 Gbit/s at 20ms", PerSecond(Bytes{125_000_000, 0}), 20 * time.Millisecond, times(MiB, 8)},
+		{"exact power of two", PerSecond(MiB), time.Second, times(MiB, 2)},
+		{"minimum", PerSecond(KB), time.Millisecond, times(KiB, 64)},
+		{"zero", PerSecond(GB), 0, times(KiB, 64)},
+		{"largest power of two", PerSecond(Bytes{0, 1 << 62}), time.Second, Bytes{0, 1 << 63}},
+		{"saturated", PerSecond(Bytes{1, 1 << 62}), time.Second, Bytes(Max)},
+		{"overflow", PerSecond(Bytes(Max)), time.Second, Bytes(Max)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestSocketBuffer(tt.rate, tt.rtt); got != tt.want {
+				t.Errorf("SuggestSocketBuffer(%v, %v) = %v, want %v", tt.rate, tt.rtt, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
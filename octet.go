@@ -0,0 +1,102 @@
+package bytesize
+
+import "strings"
+
+// octetPrefixes are the prefixes of the octet units, with the decimal and
+// binary units they start. French writes some long prefixes with an
+// accent, as in "mégaoctet", and accepts them without one. The binary
+// prefixes of 2^90 and 2^100 are "ronni" and "quetti", as in the long unit
+// names, with "robi" and "quebi" accepted as aliases.
+var octetPrefixes = []struct {
+	short []string    // decimal then binary
+	long  [2][]string // decimal, binary
+	units [2]Bytes
+}{
+	{[]string{"k", "ki"}, [2][]string{{"kilo"}, {"kibi"}}, [2]Bytes{KB, KiB}},
+	{[]string{"m", "mi"}, [2][]string{{"mega", "méga"}, {"mebi", "mébi"}}, [2]Bytes{MB, MiB}},
+	{[]string{"g", "gi"}, [2][]string{{"giga"}, {"gibi"}}, [2]Bytes{GB, GiB}},
+	{[]string{"t", "ti"}, [2][]string{{"tera", "téra"}, {"tebi", "tébi"}}, [2]Bytes{TB, TiB}},
+	{[]string{"p", "pi"}, [2][]string{{"peta", "péta"}, {"pebi", "pébi"}}, [2]Bytes{PB, PiB}},
+	{[]string{"e", "ei"}, [2][]string{{"exa"}, {"exbi"}}, [2]Bytes{EB, EiB}},
+	{[]string{"z", "zi"}, [2][]string{{"zetta"}, {"zebi"}}, [2]Bytes{ZB, ZiB}},
+	{[]string{"y", "yi"}, [2][]string{{"yotta"}, {"yobi"}}, [2]Bytes{YB, YiB}},
+	{[]string{"r", "ri"}, [2][]string{{"ronna"}, {"ronni", "robi"}}, [2]Bytes{RB, RiB}},
+	{[]string{"q", "qi"}, [2][]string{{"quetta"}, {"quetti", "quebi"}}, [2]Bytes{QB, QiB}},
+}
+
+// octetWords are the French and German words for an octet, singular and
+// plural, which follow a long prefix.
+var octetWords = []string{"octet", "octets", "oktett", "oktetts", "oktette"}
+
+// octetUnits maps the lowercased octet unit names WithOctetUnitParsing
+// accepts to their multipliers.
+var octetUnits = newOctetUnits()
+
+// newOctetUnits returns the octet unit names and their multipliers.
+func newOctetUnits() map[string]Bytes {
+	units := map[string]Bytes{"o": B}
+	for _, word := range octetWords {
+		units[word] = B
+	}
+	for _, p := range octetPrefixes {
+		for i, short := range p.short {
+			units[short+"o"] = p.units[i]
+		}
+		for i, longs := range p.long {
+			for _, long := range longs {
+				for _, word := range octetWords {
+					units[long+word] = p.units[i]
+				}
+			}
+		}
+	}
+	return units
+}
+
+// WithOctetUnits formats sizes in octets, the units French and German
+// speakers use for bytes: "o", "Ko", "Mo" and "Go", "Kio" and "Mio" for the
+// binary units, and "Octets", "Kilooctets" and so on for long unit names.
+// It has no effect on WithUnitSymbols, WithBitsOutput or Kubernetes
+// output.
+func WithOctetUnits() FormatOption {
+	return func(opts *formatOptions) error {
+		opts.octets = true
+		return nil
+	}
+}
+
+// WithOctetUnitParsing makes Parse accept octet units, as written in
+// French and German configurations, in addition to the usual units: the
+// short "o", "Ko", "Mo", "Go" and "Kio", "Mio", "Gio" and so on, and long
+// names such as "octets", "mégaoctets", "gibioctets" or "Kilooktett". Like
+// other unit names, they are not case-sensitive.
+func WithOctetUnitParsing() ParseOption {
+	return func(opts *parseOptions) error {
+		opts.octets = true
+		return nil
+	}
+}
+
+// lookupOctetUnit returns the multiplier for an octet unit name.
+func lookupOctetUnit(unitStr string) (Bytes, bool) {
+	multiplier, ok := octetUnits[strings.ToLower(strings.TrimSpace(unitStr))]
+	return multiplier, ok
+}
+
+// octetUnitName returns the octet unit name with the prefix of the byte
+// unit name, such as "Mo" for "MB" and "Kilooctet" for "Kilobyte".
+func octetUnitName(name string) string {
+	if prefix, ok := strings.CutSuffix(name, "byte"); ok {
+		return prefix + "octet"
+	}
+	if prefix, ok := strings.CutSuffix(name, "Byte"); ok {
+		if prefix == "" {
+			return "Octet"
+		}
+		return prefix + "octet"
+	}
+	if prefix, ok := strings.CutSuffix(name, "B"); ok {
+		return prefix + "o"
+	}
+	return name
+}
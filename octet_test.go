@@ -0,0 +1,118 @@
+package bytesize
+
+import "testing"
+
+// TestParseOctetUnits tests parsing French and German octet units
+func TestParseOctetUnits(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bytes
+		wantErr bool
+	}{
+		{"512 o", Bytes{512, 0}, false},
+		{"1 octet", B, false},
+		{"3 octets", Bytes{3, 0}, false},
+		{"1 Ko", KB, false},
+		{"1 ko", KB, false},
+		{"1.5 Mo", Bytes{1_500_000, 0}, false},
+		{"2 Go", times(GB, 2), false},
+		{"1 To", TB, false},
+		{"1 Kio", KiB, false},
+		{"4 Gio", times(GiB, 4), false},
+		{"1 kilooctet", KB, false},
+		{"2 mégaoctets", times(MB, 2), false},
+		{"2 megaoctets", times(MB, 2), false},
+		{"1 mébioctet", MiB, false},
+		{"1 gibioctets", GiB, false},
+		{"1 ronnioctet", RiB, false},
+		{"1 quettioctets", QiB, false},
+		{"1 quebioctets", QiB, false},
+		{"1 Kilooktett", KB, false},
+		{"2 Megaoktette", times(MB, 2), false},
+		{"1 Gigaoktetts", GB, false},
+		{"1 MB", MB, false},
+		{"1 Xo", None, true},
+		{"1 octetbytes", None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, WithOctetUnitParsing())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestParseOctetUnitsOptIn tests that octet units are rejected without
+// WithOctetUnitParsing
+func TestParseOctetUnitsOptIn(t *testing.T) {
+	for _, input := range []string{"1 Mo", "1 Go", "1 octets", "1 Kio"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) without WithOctetUnitParsing returned no error", input)
+		}
+	}
+}
+
+// TestFormatOctetUnits tests formatting sizes in octet units and parsing
+// them back
+func TestFormatOctetUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		b         Bytes
+		opts      []FormatOption
+		want      string
+		roundTrip bool
+	}{
+		{"bytes", Bytes{512, 0}, nil, "512.00 o", true},
+		{"decimal", Bytes{1_500_000, 0}, nil, "1.50 Mo", true},
+		{"binary", times(GiB, 2), []FormatOption{WithDecimalUnits(false)}, "2.00 Gio", true},
+		{"long", times(GB, 2), []FormatOption{WithLongUnits(true)}, "2.00 Gigaoctets", true},
+		{"long singular", KB, []FormatOption{WithLongUnits(true)}, "1.00 Kilooctet", true},
+		{"long bytes", Bytes{2, 0}, []FormatOption{WithLongUnits(true)}, "2.00 Octets", true},
+		{"word bytes", Bytes{2, 0}, []FormatOption{WithWordBytesBelowKB()}, "2.00 octets", true},
+		{"unit symbols", KB, []FormatOption{WithUnitSymbols(map[Bytes]string{KB: "kB"})}, "1.00 kB", true},
+		{"bits", KB, []FormatOption{WithBitsOutput()}, "8.00 Kb", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.Format(append([]FormatOption{WithOctetUnits()}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+			if !tt.roundTrip {
+				return
+			}
+			if back, err := Parse(got, WithOctetUnitParsing()); err != nil || back != tt.b {
+				t.Errorf("Parse(%q) = %v, %v, want %v", got, back, err, tt.b)
+			}
+		})
+	}
+}
+
+// TestOctetUnitsRoundTrip tests that every SI and IEC unit formatted in
+// octets, short and long, parses back
+func TestOctetUnitsRoundTrip(t *testing.T) {
+	for _, system := range []UnitSystem{SI, IEC} {
+		for _, u := range ListUnits(system) {
+			b := Bytes(Uint128(u.Factor).Mul64(3))
+			for _, long := range []bool{false, true} {
+				got, err := b.Format(WithOctetUnits(), WithDecimalUnits(system == SI), WithForcedUnit(u.Factor), WithLongUnits(long))
+				if err != nil {
+					t.Fatalf("Format() error = %v", err)
+				}
+				if back, err := Parse(got, WithOctetUnitParsing()); err != nil || back != b {
+					t.Errorf("Parse(%q) = %v, %v, want %v", got, Uint128(back), err, Uint128(b))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package bytesize
+
+import (
+	"io"
+	"math"
+)
+
+// int64Offset returns b as an int64 file offset, or a *RangeError naming fn
+// if it does not fit.
+func int64Offset(fn string, b Bytes) (int64, error) {
+	if Uint128(b).Hi != 0 || Uint128(b).Lo > math.MaxInt64 {
+		return 0, &RangeError{Func: fn, Value: Uint128(b).String()}
+	}
+	return int64(Uint128(b).Lo), nil
+}
+
+// OffsetReader returns a reader of r from off to the end of r, so that
+// code working in Bytes need not convert offsets to int64 itself. It
+// returns a *RangeError if off does not fit in an int64.
+func OffsetReader(r io.ReaderAt, off Bytes) (*io.SectionReader, error) {
+	o, err := int64Offset("OffsetReader", off)
+	if err != nil {
+		return nil, err
+	}
+	return io.NewSectionReader(r, o, math.MaxInt64-o), nil
+}
+
+// SectionReader returns a reader of the n bytes of r starting at off, like
+// io.NewSectionReader. It returns a *RangeError if off, n or the end of
+// the section does not fit in an int64.
+func SectionReader(r io.ReaderAt, off, n Bytes) (*io.SectionReader, error) {
+	o, err := int64Offset("SectionReader", off)
+	if err != nil {
+		return nil, err
+	}
+	size, err := int64Offset("SectionReader", n)
+	if err != nil {
+		return nil, err
+	}
+	if size > math.MaxInt64-o {
+		return nil, &RangeError{Func: "SectionReader", Value: Uint128(off).AddBytes(n).String()}
+	}
+	return io.NewSectionReader(r, o, size), nil
+}
+
+// OffsetWriter returns a writer to w starting at off, like
+// io.NewOffsetWriter. It returns a *RangeError if off does not fit in an
+// int64.
+func OffsetWriter(w io.WriterAt, off Bytes) (*io.OffsetWriter, error) {
+	o, err := int64Offset("OffsetWriter", off)
+	if err != nil {
+		return nil, err
+	}
+	return io.NewOffsetWriter(w, o), nil
+}
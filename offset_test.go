@@ -0,0 +1,105 @@
+package bytesize
+
+import (
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestOffsetReader tests reading from an offset to the end
+func TestOffsetReader(t *testing.T) {
+	tests := []struct {
+		off  Bytes
+		want string
+	}{
+		{None, "hello world"},
+		{Bytes{6, 0}, "world"},
+		{Bytes{11, 0}, ""},
+		{Bytes{20, 0}, ""},
+	}
+
+	for _, tt := range tests {
+		r, err := OffsetReader(strings.NewReader("hello world"), tt.off)
+		if err != nil {
+			t.Fatalf("OffsetReader(%v) error = %v", Uint128(tt.off), err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("OffsetReader(%v) read %q, %v, want %q", Uint128(tt.off), got, err, tt.want)
+		}
+	}
+
+	if _, err := OffsetReader(strings.NewReader(""), Bytes{1 << 63, 0}); !errors.Is(err, ErrRange) {
+		t.Errorf("OffsetReader(2^63) error = %v, want ErrRange", err)
+	}
+}
+
+// TestSectionReader tests reading sections of a reader
+func TestSectionReader(t *testing.T) {
+	tests := []struct {
+		off, n Bytes
+		want   string
+	}{
+		{None, Bytes{5, 0}, "hello"},
+		{Bytes{6, 0}, Bytes{5, 0}, "world"},
+		{Bytes{6, 0}, Bytes{50, 0}, "world"},
+		{Bytes{3, 0}, None, ""},
+	}
+
+	for _, tt := range tests {
+		r, err := SectionReader(strings.NewReader("hello world"), tt.off, tt.n)
+		if err != nil {
+			t.Fatalf("SectionReader(%v, %v) error = %v", Uint128(tt.off), Uint128(tt.n), err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("SectionReader(%v, %v) read %q, %v, want %q", Uint128(tt.off), Uint128(tt.n), got, err, tt.want)
+		}
+	}
+
+	rangeTests := []struct {
+		name   string
+		off, n Bytes
+	}{
+		{"offset", Bytes{0, 1}, None},
+		{"size", None, Bytes{1 << 63, 0}},
+		{"end", Bytes{1, 0}, Bytes{math.MaxInt64, 0}},
+	}
+	for _, tt := range rangeTests {
+		if _, err := SectionReader(strings.NewReader(""), tt.off, tt.n); !errors.Is(err, ErrRange) {
+			t.Errorf("SectionReader() with a large %s error = %v, want ErrRange", tt.name, err)
+		}
+	}
+}
+
+// TestOffsetWriter tests writing at an offset
+func TestOffsetWriter(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := OffsetWriter(f, Bytes{6, 0})
+	if err != nil {
+		t.Fatalf("OffsetWriter() error = %v", err)
+	}
+	if _, err := io.WriteString(w, "there"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(f.Name())
+	if err != nil || string(got) != "hello there" {
+		t.Errorf("file = %q, %v, want %q", got, err, "hello there")
+	}
+
+	if _, err := OffsetWriter(f, Bytes(Max)); !errors.Is(err, ErrRange) {
+		t.Errorf("OffsetWriter(Max) error = %v, want ErrRange", err)
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/beauhoyt/bytesize/otelbytesize
+
+go 1.24.13
+
+require (
+	github.com/beauhoyt/bytesize v0.0.0-20261016161142-f62705ad9806
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+)
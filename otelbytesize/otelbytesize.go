@@ -0,0 +1,48 @@
+// Package otelbytesize provides OpenTelemetry helpers for bytesize.Bytes
+// values, so that tracing spans and metrics annotate payload sizes
+// consistently. It is a separate module so that importing bytesize does
+// not pull in the OpenTelemetry API.
+package otelbytesize
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/beauhoyt/bytesize"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Unit is the UCUM unit for bytes, for use with metric.WithUnit when
+// creating instruments that record bytesize.Bytes values.
+const Unit = "By"
+
+// Attribute returns an attribute holding b. Values that fit in an int64 are
+// stored as an int64 attribute; larger values are stored as a string
+// attribute holding the exact count in base 10.
+func Attribute(key string, b bytesize.Bytes) attribute.KeyValue {
+	if n, ok := int64Value(b); ok {
+		return attribute.Int64(key, n)
+	}
+	return attribute.String(key, bytesize.Uint128(b).String())
+}
+
+// Record records b in the histogram h. It returns an error, and records
+// nothing, if b does not fit in an int64.
+func Record(ctx context.Context, h metric.Int64Histogram, b bytesize.Bytes, opts ...metric.RecordOption) error {
+	n, ok := int64Value(b)
+	if !ok {
+		return fmt.Errorf("value overflows int64: %s", bytesize.Uint128(b))
+	}
+	h.Record(ctx, n, opts...)
+	return nil
+}
+
+// int64Value returns b as an int64, and whether it fits.
+func int64Value(b bytesize.Bytes) (int64, bool) {
+	if b.Hi != 0 || b.Lo > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(b.Lo), true
+}
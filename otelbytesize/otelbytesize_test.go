@@ -0,0 +1,60 @@
+package otelbytesize
+
+import (
+	"context"
+	"testing"
+
+	"github.com/beauhoyt/bytesize"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// TestAttribute tests that attributes are int64 when representable and
+// strings otherwise
+func TestAttribute(t *testing.T) {
+	tests := []struct {
+		name  string
+		input bytesize.Bytes
+		want  attribute.KeyValue
+	}{
+		{"zero", bytesize.None, attribute.Int64("size", 0)},
+		{"megabyte", bytesize.MB, attribute.Int64("size", 1_000_000)},
+		{"max int64", bytesize.Bytes{Lo: 1<<63 - 1}, attribute.Int64("size", 1<<63-1)},
+		{"above int64", bytesize.Bytes{Lo: 1 << 63}, attribute.String("size", "9223372036854775808")},
+		{"zebibyte", bytesize.ZiB, attribute.String("size", "1180591620717411303424")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Attribute("size", tt.input); got != tt.want {
+				t.Errorf("Attribute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeHistogram struct {
+	embedded.Int64Histogram
+	recorded []int64
+}
+
+func (h *fakeHistogram) Record(_ context.Context, incr int64, _ ...metric.RecordOption) {
+	h.recorded = append(h.recorded, incr)
+}
+
+// TestRecord tests recording sizes in a histogram
+func TestRecord(t *testing.T) {
+	h := &fakeHistogram{}
+	ctx := context.Background()
+
+	if err := Record(ctx, h, bytesize.KiB); err != nil {
+		t.Fatalf("Record(KiB) error = %v", err)
+	}
+	if err := Record(ctx, h, bytesize.ZiB); err == nil {
+		t.Errorf("Record(ZiB) should have errored")
+	}
+	if len(h.recorded) != 1 || h.recorded[0] != 1024 {
+		t.Errorf("recorded = %v, want [1024]", h.recorded)
+	}
+}
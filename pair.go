@@ -0,0 +1,35 @@
+package bytesize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePair parses two sizes separated by sep, such as the "3.4G/10G" or
+// "512MiB of 2GiB" that status endpoints report, and returns both so that
+// the caller can compute a ratio. Whitespace around sep is ignored, and
+// each size is parsed like Parse with opts; "3.4G/10G" needs
+// WithKubernetesSuffixParsing for its single letter units.
+func ParsePair(s, sep string, opts ...ParseOption) (a, b Bytes, err error) {
+	if sep == "" {
+		return Bytes{}, Bytes{}, fmt.Errorf("empty separator")
+	}
+	first, second, ok := strings.Cut(s, sep)
+	if !ok {
+		return Bytes{}, Bytes{}, fmt.Errorf("missing separator %q in %q", sep, s)
+	}
+
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return Bytes{}, Bytes{}, err
+	}
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	if a, _, err = parse(strings.TrimSpace(first), parseOptions, sc, nil); err != nil {
+		return Bytes{}, Bytes{}, fmt.Errorf("first size of %q: %w", s, err)
+	}
+	if b, _, err = parse(strings.TrimSpace(second), parseOptions, sc, nil); err != nil {
+		return Bytes{}, Bytes{}, fmt.Errorf("second size of %q: %w", s, err)
+	}
+	return a, b, nil
+}
@@ -0,0 +1,46 @@
+package bytesize
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParsePair tests parsing two sizes separated by a separator
+func TestParsePair(t *testing.T) {
+	tests := []struct {
+		input   string
+		sep     string
+		opts    []ParseOption
+		wantA   Bytes
+		wantB   Bytes
+		wantErr string
+	}{
+		{"512MiB of 2GiB", "of", nil, times(MiB, 512), times(GiB, 2), ""},
+		{"1 GB / 10 GB", "/", nil, GB, times(GB, 10), ""},
+		{"3.4G/10G", "/", []ParseOption{WithKubernetesSuffixParsing()}, Bytes{3_400_000_000, 0}, times(GB, 10), ""},
+		{"0 B/0 B", "/", nil, None, None, ""},
+		{"3.4G/10G", "/", nil, None, None, "first size of"},
+		{"1 GB/lots", "/", nil, None, None, "second size of"},
+		{"1 GB/", "/", nil, None, None, "empty string"},
+		{"1 GB", "/", nil, None, None, "missing separator"},
+		{"1 GB/2 GB", "", nil, None, None, "empty separator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			a, b, err := ParsePair(tt.input, tt.sep, tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParsePair(%q, %q) error = %v, want containing %q", tt.input, tt.sep, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePair(%q, %q) error = %v", tt.input, tt.sep, err)
+			}
+			if a != tt.wantA || b != tt.wantB {
+				t.Errorf("ParsePair(%q, %q) = %v, %v, want %v, %v", tt.input, tt.sep, a, b, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// referencePrec is the mantissa precision, in bits, used by the big.Float
+// reference implementation. It is far larger than needed for any 128-bit
+// result so that the reference is only ever off in the last few of these
+// bits, never in the integer part.
+const referencePrec = 1024
+
+// Trace records the steps ParseDebug took to turn a string into a Bytes
+// value. It is meant for debugging precision disputes such as whether
+// "3.14159 KB" is 3141 or 3142 bytes.
+type Trace struct {
+	// Number is the numeric part of the input as tokenized.
+	Number string
+	// Unit is the unit part of the input as tokenized.
+	Unit string
+	// Multiplier is the value of Unit in bytes.
+	Multiplier Bytes
+	// Exact is the exact product of Number and Multiplier as a fraction,
+	// e.g. "314159/100".
+	Exact string
+	// Reference is the product of Number and Multiplier as computed by the
+	// big.Float reference implementation.
+	Reference string
+	// Remainder is the fractional part of Exact that was discarded, as a
+	// fraction.
+	Remainder string
+	// Steps lists every step in the order it was performed.
+	Steps []string
+}
+
+// String returns the steps of the trace, one per line.
+func (t Trace) String() string {
+	return strings.Join(t.Steps, "\n")
+}
+
+// record appends a step to the trace. It is a no-op on a nil trace, so
+// parse can call it unconditionally.
+func (t *Trace) record(format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, fmt.Sprintf(format, args...))
+}
+
+// tokens records the result of tokenizing the input.
+func (t *Trace) tokens(num, unit string) {
+	if t == nil {
+		return
+	}
+	t.Number, t.Unit = num, unit
+	t.record("tokenized number %q and unit %q", num, unit)
+}
+
+// multiplier records the multiplier the unit resolved to.
+func (t *Trace) multiplier(m Bytes) {
+	if t == nil {
+		return
+	}
+	t.Multiplier = m
+	t.record("unit %q is %s bytes", t.Unit, Uint128(m))
+}
+
+// product records the exact product, the reference product and the part
+// discarded when truncating to whole bytes.
+func (t *Trace) product(num string, m Bytes, exact *big.Rat, whole *big.Int) {
+	if t == nil {
+		return
+	}
+	t.Exact = exact.RatString()
+	t.record("%s × %s = %s exactly", num, Uint128(m), t.Exact)
+	if ref, err := referenceProduct(num, m); err == nil {
+		t.Reference = ref.Text('g', 50)
+		t.record("reference big.Float product is %s", t.Reference)
+	}
+	rem := new(big.Rat).Sub(exact, new(big.Rat).SetInt(whole))
+	t.Remainder = rem.RatString()
+	t.record("truncated to %s, discarding %s", whole, t.Remainder)
+}
+
+// ParseDebug parses s exactly like Parse, additionally returning a Trace of
+// how the input was tokenized and how the result was computed. The trace
+// is populated as far as parsing got, even when an error is returned.
+func ParseDebug(s string, opts ...ParseOption) (Bytes, Trace, error) {
+	var tr Trace
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return Bytes{}, tr, err
+	}
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	b, _, err := parse(s, parseOptions, sc, &tr)
+	if err != nil {
+		tr.record("error: %v", err)
+	}
+	return b, tr, err
+}
+
+// referenceProduct computes num × m using big.Float arithmetic at
+// referencePrec bits of precision. It is an independent implementation of
+// the arithmetic in Parse, which uses exact big.Rat arithmetic, and is used
+// to cross-check it.
+func referenceProduct(num string, m Bytes) (*big.Float, error) {
+	f, _, err := big.ParseFloat(num, 10, referencePrec, big.ToNearestEven)
+	if err != nil {
+		return nil, err
+	}
+	mf := new(big.Float).SetPrec(referencePrec).SetInt(Uint128(m).Big())
+	return f.Mul(f, mf), nil
+}
+
+// agreesWithReference reports whether got is the truncation of ref. Since
+// ref is only accurate to a relative 2^-(referencePrec/2), a ref within that
+// tolerance of an integer must round to exactly that integer.
+func agreesWithReference(got Bytes, ref *big.Float) bool {
+	tol := new(big.Float).SetPrec(referencePrec).Abs(ref)
+	if tol.Cmp(big.NewFloat(1)) < 0 {
+		tol.SetInt64(1)
+	}
+	tol.SetMantExp(tol, -referencePrec/2)
+
+	half := new(big.Float).SetPrec(referencePrec).SetFloat64(0.5)
+	nearest, _ := new(big.Float).SetPrec(referencePrec).Add(ref, half).Int(nil)
+	diff := new(big.Float).SetPrec(referencePrec).SetInt(nearest)
+	if diff.Sub(diff, ref).Abs(diff).Cmp(tol) <= 0 {
+		return Uint128(got).Big().Cmp(nearest) == 0
+	}
+
+	floor, _ := ref.Int(nil)
+	return Uint128(got).Big().Cmp(floor) == 0
+}
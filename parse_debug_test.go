@@ -0,0 +1,133 @@
+package bytesize
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseDebug tests that ParseDebug records the tokens and arithmetic
+// behind a parse
+func TestParseDebug(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      Bytes
+		number    string
+		unit      string
+		exact     string
+		remainder string
+	}{
+		{"3.14159 KB", Bytes{3141, 0}, "3.14159", "KB", "314159/100", "59/100"},
+		{"1.5 KiB", Bytes{1536, 0}, "1.5", "KiB", "1536", "0"},
+		{"10 MB", Bytes{10_000_000, 0}, "10", "MB", "10000000", "0"},
+		{"0.00001 KB", Bytes{}, "0.00001", "KB", "1/100", "1/100"},
+		{"  7 bytes ", Bytes{7, 0}, "7", "bytes", "7", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, tr, err := ParseDebug(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDebug(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDebug(%q) = %v, want %v", tt.input, Uint128(got), Uint128(tt.want))
+			}
+			if tr.Number != tt.number || tr.Unit != tt.unit {
+				t.Errorf("ParseDebug(%q) tokens = (%q, %q), want (%q, %q)", tt.input, tr.Number, tr.Unit, tt.number, tt.unit)
+			}
+			if tr.Exact != tt.exact {
+				t.Errorf("ParseDebug(%q) Exact = %q, want %q", tt.input, tr.Exact, tt.exact)
+			}
+			if tr.Remainder != tt.remainder {
+				t.Errorf("ParseDebug(%q) Remainder = %q, want %q", tt.input, tr.Remainder, tt.remainder)
+			}
+			if tr.Reference == "" {
+				t.Errorf("ParseDebug(%q) Reference is empty", tt.input)
+			}
+			if !strings.Contains(tr.String(), "input") {
+				t.Errorf("ParseDebug(%q) trace does not start with the input:\n%s", tt.input, tr)
+			}
+		})
+	}
+}
+
+// TestParseDebugErrors tests that ParseDebug returns the same errors as
+// Parse and records them in the trace
+func TestParseDebugErrors(t *testing.T) {
+	inputs := []string{"", "MB", "10 XB", "-5 MB", "1.2.3 KB"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, wantErr := Parse(input)
+			_, tr, err := ParseDebug(input)
+			if err == nil || wantErr == nil || err.Error() != wantErr.Error() {
+				t.Fatalf("ParseDebug(%q) error = %v, want %v", input, err, wantErr)
+			}
+			last := tr.Steps[len(tr.Steps)-1]
+			if !strings.Contains(last, err.Error()) {
+				t.Errorf("ParseDebug(%q) last step = %q, want it to contain %q", input, last, err)
+			}
+		})
+	}
+}
+
+// TestAgreesWithReference tests the tolerance check used by the
+// differential fuzzer
+func TestAgreesWithReference(t *testing.T) {
+	tests := []struct {
+		num  string
+		unit Bytes
+		got  Bytes
+		want bool
+	}{
+		{"3.14159", KB, Bytes{3141, 0}, true},
+		{"3.14159", KB, Bytes{3142, 0}, false},
+		{"3.14159", KB, Bytes{3140, 0}, false},
+		{"0.3", KB, Bytes{300, 0}, true},
+		{"0.3", KB, Bytes{299, 0}, false},
+		{"1", QiB, QiB, true},
+	}
+
+	for _, tt := range tests {
+		ref, err := referenceProduct(tt.num, tt.unit)
+		if err != nil {
+			t.Fatalf("referenceProduct(%q) error = %v", tt.num, err)
+		}
+		if got := agreesWithReference(tt.got, ref); got != tt.want {
+			t.Errorf("agreesWithReference(%v, %s × %v) = %v, want %v", Uint128(tt.got), tt.num, Uint128(tt.unit), got, tt.want)
+		}
+	}
+}
+
+// FuzzReferenceParse differentially fuzzes Parse against the big.Float
+// reference implementation
+func FuzzReferenceParse(f *testing.F) {
+	seedInputs := []string{
+		"3.14159 KB",
+		"0.1 KB",
+		"0.3 MB",
+		"1.5 GiB",
+		"0.00001 KB",
+		"123456789.123456789 QiB",
+		"340282366920938463463374607431768211455 B",
+		"1 kilobyte",
+	}
+
+	for _, seed := range seedInputs {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		got, tr, err := ParseDebug(input)
+		if err != nil {
+			return
+		}
+		ref, err := referenceProduct(tr.Number, tr.Multiplier)
+		if err != nil {
+			t.Fatalf("Parse(%q) succeeded but the reference failed: %v", input, err)
+		}
+		if !agreesWithReference(got, ref) {
+			t.Errorf("Parse(%q) = %v, reference = %s", input, Uint128(got), ref.Text('g', 50))
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package bytesize
+
+// ParsedValue is a parsed byte size together with how it was written.
+type ParsedValue struct {
+	// Number is the numeric literal as written, e.g. "2048".
+	Number string
+	// Unit is the unit as written, e.g. "Mi" or "megabytes".
+	Unit string
+	// Factor is the value of Unit in bytes.
+	Factor Bytes
+	// Bytes is the parsed size.
+	Bytes Bytes
+	// Exact reports whether Bytes is exactly Number × Factor, i.e. whether
+	// no fraction of a byte was rounded away.
+	Exact bool
+}
+
+// ParseDetailed parses s like Parse, additionally returning the numeric
+// literal and unit as the user wrote them, so that tools can render values
+// back in the unit the user chose.
+func ParseDetailed(s string, opts ...ParseOption) (ParsedValue, error) {
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return ParsedValue{}, err
+	}
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	b, exact, err := parse(s, parseOptions, sc, nil)
+	if err != nil {
+		return ParsedValue{}, err
+	}
+	// s parsed, so it tokenizes and its unit is known
+	if parseOptions.lenientUnits {
+		s = foldLookalikes(s)
+	}
+	number, unit, _ := tokenize(s, parseOptions.lenientUnits)
+	factor, _ := parseOptions.unitMultiplier(unit)
+	return ParsedValue{
+		Number: number,
+		Unit:   unit,
+		Factor: factor,
+		Bytes:  b,
+		Exact:  exact,
+	}, nil
+}
+
+// String returns the number and unit as written, separated by a space.
+func (p ParsedValue) String() string {
+	return p.Number + " " + p.Unit
+}
+
+// AsUnit returns the unit as written as a Unit, for formatting other
+// values in the same unit with WithUnit. Both names of the returned Unit
+// are the unit as written, so it is meant for short unit formatting.
+func (p ParsedValue) AsUnit() Unit {
+	system := SI
+	if u, ok := UnitOf(p.Factor); ok {
+		system = u.System
+	}
+	return Unit{Factor: p.Factor, Short: p.Unit, Long: p.Unit, Symbol: p.Unit, System: system}
+}
@@ -0,0 +1,55 @@
+package bytesize
+
+import "testing"
+
+// TestParseDetailed tests that ParseDetailed captures the literal and unit
+// as written
+func TestParseDetailed(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ParsedValue
+	}{
+		{"2048 MiB", ParsedValue{"2048", "MiB", MiB, Bytes(Uint128(MiB).Mul64(2048)), true}},
+		{"  1.5gigabytes ", ParsedValue{"1.5", "gigabytes", GB, Bytes{1_500_000_000, 0}, true}},
+		{"0.5 B", ParsedValue{"0.5", "B", B, Bytes{}, false}},
+		{"10 kB", ParsedValue{"10", "kB", KB, Bytes{10_000, 0}, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDetailed(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDetailed(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDetailed(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseDetailed("10 XB"); err == nil {
+		t.Error("ParseDetailed(\"10 XB\") returned no error")
+	}
+}
+
+// TestParsedValueEchoBack tests rendering values in the unit the user wrote
+func TestParsedValueEchoBack(t *testing.T) {
+	p, err := ParseDetailed("2048MiB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.String(); got != "2048 MiB" {
+		t.Errorf("String() = %q, want %q", got, "2048 MiB")
+	}
+
+	got, err := p.Bytes.Format(WithUnit(p.AsUnit()), WithFormatString("%.0f%s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "2048MiB" {
+		t.Errorf("Format(WithUnit(AsUnit())) = %q, want %q", got, "2048MiB")
+	}
+	if sys := p.AsUnit().System; sys != IEC {
+		t.Errorf("AsUnit().System = %v, want IEC", sys)
+	}
+}
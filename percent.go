@@ -0,0 +1,33 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// percentRat returns 100 × part / whole exactly. whole must not be zero.
+func percentRat(part, whole Bytes) *big.Rat {
+	r := new(big.Rat).SetFrac(Uint128(part).Big(), Uint128(whole).Big())
+	return r.Mul(r, big.NewRat(100, 1))
+}
+
+// Percent returns part as a percentage of whole, e.g. 25 for a quarter. It
+// returns an error if whole is zero.
+func Percent(part, whole Bytes) (float64, error) {
+	if Uint128(whole).IsZero() {
+		return 0, fmt.Errorf("percent of zero bytes")
+	}
+	f, _ := percentRat(part, whole).Float64()
+	return f, nil
+}
+
+// FormatPercent formats part as a percentage of whole with precision digits
+// after the decimal point, e.g. "34.7%". The percentage is computed exactly
+// and rounded half away from zero. A negative precision is treated as zero.
+// If whole is zero the percentage is undefined and "NaN%" is returned.
+func FormatPercent(part, whole Bytes, precision int) string {
+	if Uint128(whole).IsZero() {
+		return "NaN%"
+	}
+	return percentRat(part, whole).FloatString(max(precision, 0)) + "%"
+}
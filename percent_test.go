@@ -0,0 +1,56 @@
+package bytesize
+
+import "testing"
+
+// TestPercent tests computing part as a percentage of whole
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		part, whole Bytes
+		want        float64
+	}{
+		{Bytes{250, 0}, KB, 25},
+		{KB, KB, 100},
+		{GB, MB, 100_000},
+		{None, GB, 0},
+		{Bytes(Max), Bytes(Max), 100},
+	}
+
+	for _, tt := range tests {
+		got, err := Percent(tt.part, tt.whole)
+		if err != nil {
+			t.Fatalf("Percent(%v, %v) error = %v", Uint128(tt.part), Uint128(tt.whole), err)
+		}
+		if got != tt.want {
+			t.Errorf("Percent(%v, %v) = %v, want %v", Uint128(tt.part), Uint128(tt.whole), got, tt.want)
+		}
+	}
+
+	if _, err := Percent(KB, None); err == nil {
+		t.Error("Percent(KB, 0) returned no error")
+	}
+}
+
+// TestFormatPercent tests formatting part as a percentage of whole
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		part, whole Bytes
+		precision   int
+		want        string
+	}{
+		{Bytes{347, 0}, KB, 1, "34.7%"},
+		{Bytes{1, 0}, Bytes{3, 0}, 2, "33.33%"},
+		{Bytes{2, 0}, Bytes{3, 0}, 0, "67%"},
+		{Bytes{1, 0}, Bytes{8, 0}, 1, "12.5%"},
+		{Bytes{1, 0}, Bytes{16, 0}, 1, "6.3%"},
+		{Bytes{1, 0}, Bytes{3, 0}, -1, "33%"},
+		{KB, KB, 0, "100%"},
+		{QiB, Bytes(Max), 4, "0.0000%"},
+		{KB, None, 1, "NaN%"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPercent(tt.part, tt.whole, tt.precision); got != tt.want {
+			t.Errorf("FormatPercent(%v, %v, %d) = %q, want %q", Uint128(tt.part), Uint128(tt.whole), tt.precision, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package bytesize
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParsePrefix parses the size at the start of s, such as "500 MB" in
+// "500 MB in 12s", like Parse with opts, and returns it with the length of
+// the prefix of s that it occupies, so that the caller can go on to parse
+// the rest. The size is a number, optionally followed by whitespace, and a
+// unit, which is the run of letters after the number, so "12s" is an error
+// rather than 12 bytes followed by "s".
+func ParsePrefix(s string, opts ...ParseOption) (Bytes, int, error) {
+	parseOptions, err := newParseOptions(opts...)
+	if err != nil {
+		return Bytes{}, 0, err
+	}
+
+	// Find the end of the number and of the word after it
+	i := skipSpaceAt(s, 0)
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	numEnd := i
+	for numEnd < len(s) && (s[numEnd] >= '0' && s[numEnd] <= '9' || s[numEnd] == '.') {
+		numEnd++
+	}
+	if numEnd == i {
+		return Bytes{}, 0, &SyntaxError{s, i, "invalid number: no digits"}
+	}
+	unitEnd := skipSpaceAt(s, numEnd)
+	unitStart := unitEnd
+	for unitEnd < len(s) {
+		r, size := utf8.DecodeRuneInString(s[unitEnd:])
+		if !unicode.IsLetter(r) {
+			break
+		}
+		unitEnd += size
+	}
+
+	if unitEnd == unitStart {
+		return Bytes{}, 0, &SyntaxError{s, unitStart, "missing unit"}
+	}
+	sc := getRatScratch()
+	defer putRatScratch(sc)
+	b, _, err := parse(s[:unitEnd], parseOptions, sc, nil)
+	if err != nil {
+		return Bytes{}, 0, fmt.Errorf("invalid size prefix %q: %w", s[:unitEnd], err)
+	}
+	return b, unitEnd, nil
+}
+
+// skipSpaceAt returns the index of the first rune of s at or after i that is
+// not whitespace, or len(s) if there is none.
+func skipSpaceAt(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
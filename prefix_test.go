@@ -0,0 +1,37 @@
+package bytesize
+
+import "testing"
+
+// TestParsePrefix tests parsing the size at the start of a string
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Bytes
+		wantN   int
+		wantErr bool
+	}{
+		{"500 MB in 12s", times(MB, 500), 6, false},
+		{"1.5GiB/s", Bytes{1 << 30 * 3 / 2, 0}, 6, false},
+		{"  2 kilobytes, then more", times(KB, 2), 13, false},
+		{"12s", None, 0, true},
+		{"42 apples", None, 0, true},
+		{"7", None, 0, true},
+		{"10 MB", times(MB, 10), 5, false},
+		{"MB", None, 0, true},
+		{"-5 MB", None, 0, true},
+		{"1.2.3 MB", None, 0, true},
+		{"", None, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, n, err := ParsePrefix(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePrefix(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want || n != tt.wantN {
+				t.Errorf("ParsePrefix(%q) = %v, %d, want %v, %d", tt.in, got, n, tt.want, tt.wantN)
+			}
+		})
+	}
+}
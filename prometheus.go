@@ -0,0 +1,71 @@
+package bytesize
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WriteMetric writes b to w as a gauge in the Prometheus text exposition
+// format, preceded by HELP and TYPE comments. The sample value is in bytes
+// and the HELP comment gives its human-readable form. Labels are written
+// in sorted order. It returns an error if name or a label name is not a
+// valid Prometheus name, or if writing to w fails.
+func WriteMetric(w io.Writer, name string, b Bytes, labels map[string]string) error {
+	if !isMetricName(name) {
+		return fmt.Errorf("invalid metric name: %q", name)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# HELP %s Size in bytes (%s)\n", name, b)
+	fmt.Fprintf(&sb, "# TYPE %s gauge\n", name)
+	sb.WriteString(name)
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i, key := range slices.Sorted(maps.Keys(labels)) {
+			if !isLabelName(key) {
+				return fmt.Errorf("invalid label name: %q", key)
+			}
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			fmt.Fprintf(&sb, "%s=\"%s\"", key, labelValueEscaper.Replace(labels[key]))
+		}
+		sb.WriteByte('}')
+	}
+	fmt.Fprintf(&sb, " %s\n", Uint128(b))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// labelValueEscaper escapes a label value for the text exposition format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// isMetricName reports whether s matches [a-zA-Z_:][a-zA-Z0-9_:]*.
+func isMetricName(s string) bool {
+	return isPrometheusName(s, true)
+}
+
+// isLabelName reports whether s matches [a-zA-Z_][a-zA-Z0-9_]*.
+func isLabelName(s string) bool {
+	return isPrometheusName(s, false)
+}
+
+func isPrometheusName(s string, colons bool) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case r == ':' && colons:
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
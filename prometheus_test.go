@@ -0,0 +1,98 @@
+package bytesize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestWriteMetric tests writing sizes in the Prometheus text format
+func TestWriteMetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric string
+		b      Bytes
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "no labels",
+			metric: "disk_used_bytes",
+			b:      Bytes{1_500_000_000, 0},
+			want: "# HELP disk_used_bytes Size in bytes (1.50 GB)\n" +
+				"# TYPE disk_used_bytes gauge\n" +
+				"disk_used_bytes 1500000000\n",
+		},
+		{
+			name:   "sorted labels",
+			metric: "cache:size_bytes",
+			b:      KiB,
+			labels: map[string]string{"tier": "hot", "node": "a"},
+			want: "# HELP cache:size_bytes Size in bytes (1.02 KB)\n" +
+				"# TYPE cache:size_bytes gauge\n" +
+				"cache:size_bytes{node=\"a\",tier=\"hot\"} 1024\n",
+		},
+		{
+			name:   "escaped label value",
+			metric: "x",
+			b:      None,
+			labels: map[string]string{"path": "C:\\tmp\n\"q\""},
+			want: "# HELP x Size in bytes (0.00 B)\n" +
+				"# TYPE x gauge\n" +
+				"x{path=\"C:\\\\tmp\\n\\\"q\\\"\"} 0\n",
+		},
+		{
+			name:   "beyond uint64",
+			metric: "huge",
+			b:      QiB,
+			want: "# HELP huge Size in bytes (1.27 QB)\n" +
+				"# TYPE huge gauge\n" +
+				"huge 1267650600228229401496703205376\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := WriteMetric(&sb, tt.metric, tt.b, tt.labels); err != nil {
+				t.Fatalf("WriteMetric() error = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("WriteMetric() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+// TestWriteMetricErrors tests that WriteMetric rejects invalid names and
+// reports write errors
+func TestWriteMetricErrors(t *testing.T) {
+	var sb strings.Builder
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+	}{
+		{"empty name", "", nil},
+		{"leading digit", "1bytes", nil},
+		{"dash", "disk-bytes", nil},
+		{"colon in label", "x", map[string]string{"a:b": "v"}},
+		{"leading digit label", "x", map[string]string{"0a": "v"}},
+	}
+	for _, tt := range tests {
+		if err := WriteMetric(&sb, tt.metric, KB, tt.labels); err == nil {
+			t.Errorf("%s: WriteMetric() returned no error", tt.name)
+		}
+	}
+	if sb.Len() != 0 {
+		t.Errorf("WriteMetric() wrote %q despite errors", sb.String())
+	}
+
+	if err := WriteMetric(failingWriter{}, "x", KB, nil); err == nil {
+		t.Error("WriteMetric() to a failing writer returned no error")
+	}
+}
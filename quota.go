@@ -0,0 +1,85 @@
+package bytesize
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrQuotaExceeded is the error matched, via errors.Is, by every
+// *QuotaExceededError returned from Quota.Reserve.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaExceededError is returned by Quota.Reserve when a reservation does not
+// fit in the remaining quota.
+type QuotaExceededError struct {
+	Requested Bytes // the size of the rejected reservation
+	Remaining Bytes // what was left in the quota at the time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota exceeded: requested %s, %s remaining", e.Requested, e.Remaining)
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// Quota tracks usage against a fixed limit. It is safe for concurrent use.
+type Quota struct {
+	mu    sync.Mutex
+	limit Bytes
+	used  Bytes
+}
+
+// NewQuota returns a Quota allowing up to limit bytes to be reserved.
+func NewQuota(limit Bytes) *Quota {
+	return &Quota{limit: limit}
+}
+
+// Reserve reserves n bytes of the quota. If fewer than n bytes remain, no
+// reservation is made and a *QuotaExceededError is returned.
+func (q *Quota) Reserve(n Bytes) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	remaining := Bytes(Uint128(q.limit).SubBytes(q.used))
+	if Uint128(n).CmpBytes(remaining) > 0 {
+		return &QuotaExceededError{Requested: n, Remaining: remaining}
+	}
+	q.used = Bytes(Uint128(q.used).AddBytes(n))
+	return nil
+}
+
+// Release returns n previously reserved bytes to the quota. Releasing more
+// than is in use leaves the quota empty.
+func (q *Quota) Release(n Bytes) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if Uint128(n).CmpBytes(q.used) >= 0 {
+		q.used = None
+		return
+	}
+	q.used = Bytes(Uint128(q.used).SubBytes(n))
+}
+
+// Limit returns the size of the quota.
+func (q *Quota) Limit() Bytes {
+	return q.limit
+}
+
+// Used returns the number of bytes currently reserved.
+func (q *Quota) Used() Bytes {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.used
+}
+
+// Remaining returns the number of bytes still available for reservation.
+func (q *Quota) Remaining() Bytes {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return Bytes(Uint128(q.limit).SubBytes(q.used))
+}
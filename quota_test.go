@@ -0,0 +1,85 @@
+package bytesize
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// TestQuota tests reserving and releasing against a quota
+func TestQuota(t *testing.T) {
+	q := NewQuota(KB)
+
+	if err := q.Reserve(Bytes{600, 0}); err != nil {
+		t.Fatalf("Reserve(600) error = %v", err)
+	}
+	if got := q.Used(); got != (Bytes{600, 0}) {
+		t.Errorf("Used() = %v, want 600", Uint128(got))
+	}
+	if got := q.Remaining(); got != (Bytes{400, 0}) {
+		t.Errorf("Remaining() = %v, want 400", Uint128(got))
+	}
+
+	err := q.Reserve(Bytes{500, 0})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Reserve(500) error = %v, want ErrQuotaExceeded", err)
+	}
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Reserve(500) error = %T, want *QuotaExceededError", err)
+	}
+	if quotaErr.Requested != (Bytes{500, 0}) || quotaErr.Remaining != (Bytes{400, 0}) {
+		t.Errorf("QuotaExceededError = %+v, want requested 500 and remaining 400", quotaErr)
+	}
+	if got := q.Used(); got != (Bytes{600, 0}) {
+		t.Errorf("Used() after failed Reserve = %v, want 600", Uint128(got))
+	}
+
+	if err := q.Reserve(Bytes{400, 0}); err != nil {
+		t.Fatalf("Reserve(400) error = %v", err)
+	}
+	if got := q.Remaining(); got != None {
+		t.Errorf("Remaining() = %v, want 0", Uint128(got))
+	}
+
+	q.Release(Bytes{100, 0})
+	if got := q.Used(); got != (Bytes{900, 0}) {
+		t.Errorf("Used() after Release(100) = %v, want 900", Uint128(got))
+	}
+	q.Release(GB)
+	if got := q.Used(); got != None {
+		t.Errorf("Used() after over-release = %v, want 0", Uint128(got))
+	}
+	if got := q.Limit(); got != KB {
+		t.Errorf("Limit() = %v, want %v", Uint128(got), Uint128(KB))
+	}
+}
+
+// TestQuotaConcurrent tests that concurrent reservations never exceed the
+// limit
+func TestQuotaConcurrent(t *testing.T) {
+	q := NewQuota(Bytes{100, 0})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	granted := 0
+	for range 1000 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if q.Reserve(B) == nil {
+				mu.Lock()
+				granted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if granted != 100 {
+		t.Errorf("granted %d reservations, want 100", granted)
+	}
+	if got := q.Remaining(); got != None {
+		t.Errorf("Remaining() = %v, want 0", Uint128(got))
+	}
+}
@@ -0,0 +1,230 @@
+package bytesize
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Rate is a transfer rate of Amount bytes every Per, such as 100 MB per
+// second.
+type Rate struct {
+	Amount Bytes
+	Per    time.Duration
+}
+
+// PerSecond returns the rate of b bytes per second.
+func PerSecond(b Bytes) Rate {
+	return Rate{Amount: b, Per: time.Second}
+}
+
+// String returns the rate with Amount formatted as by Bytes.String and Per
+// as "/s", "/min" or "/h" for those durations, e.g. "1.50 MB/s", or as
+// "/" and a time.Duration otherwise, e.g. "1.00 KB/10ms".
+func (r Rate) String() string {
+	return r.Amount.String() + perName(r.Per)
+}
+
+// Format returns the rate with Amount formatted by Bytes.Format with opts
+// and Per written as by String, e.g. "1.50 MiB/s", or "100.00 Mb/s" with
+// WithBitsOutput. ParseRate parses the result back into the same rate,
+// apart from any rounding of Amount. It returns an error if any of the
+// options are invalid.
+func (r Rate) Format(opts ...FormatOption) (string, error) {
+	amount, err := r.Amount.Format(opts...)
+	if err != nil {
+		return "", err
+	}
+	return amount + perName(r.Per), nil
+}
+
+// perName returns the slash and period String writes for per.
+func perName(per time.Duration) string {
+	switch per {
+	case time.Second:
+		return "/s"
+	case time.Minute:
+		return "/min"
+	case time.Hour:
+		return "/h"
+	default:
+		return "/" + per.String()
+	}
+}
+
+// ParseRate parses a rate written as a size, a slash and a period, such as
+// "100 MB/s", "2 GiB/h" or "1.00 KB/10ms", or as a size with the suffix
+// "ps" for per second, such as "100 MBps" or "100 Mbps". The size is parsed
+// like Parse with opts, except that a unit ending in a lowercase "b" or in
+// "bit" or "bits" counts bits rather than bytes, so "Mb/s", "Mbit/s" and
+// "Mbps" are all megabits per second. The period is a name such as "s",
+// "min" or "h", case insensitive, or a duration accepted by
+// time.ParseDuration.
+//
+// The rate is kept exact rather than converted to whole bytes per second:
+// "1 GB/min" is 1 GB per minute, and a number of bits that is not a whole
+// number of bytes is kept as that many bytes per eight periods, so "1 bps"
+// is 1 B/8s.
+func ParseRate(s string, opts ...ParseOption) (Rate, error) {
+	amountStr, periodStr, ok := strings.Cut(s, "/")
+	per := time.Second
+	if ok {
+		var err error
+		if per, err = parsePeriod(periodStr); err != nil {
+			return Rate{}, fmt.Errorf("invalid rate %q: %w", s, err)
+		}
+	} else {
+		amountStr = strings.TrimSpace(amountStr)
+		var found bool
+		if amountStr, found = strings.CutSuffix(amountStr, "ps"); !found {
+			return Rate{}, fmt.Errorf("invalid rate %q: missing '/' or \"ps\"", s)
+		}
+	}
+
+	amountStr, bits := bitsToBytes(strings.TrimSpace(amountStr))
+	amount, err := Parse(amountStr, opts...)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid rate %q: %w", s, err)
+	}
+	if !bits {
+		return Rate{Amount: amount, Per: per}, nil
+	}
+	if Uint128(amount).Lo%8 == 0 {
+		return Rate{Amount: Bytes(Uint128(amount).Rsh(3)), Per: per}, nil
+	}
+	if per > math.MaxInt64/8 {
+		return Rate{}, fmt.Errorf("invalid rate %q: period too long", s)
+	}
+	return Rate{Amount: amount, Per: 8 * per}, nil
+}
+
+// ParseThroughputPhrase parses a transfer written as a size, the word "in"
+// and a duration, such as "500 MB in 12s" from a log line, returning the
+// size, the duration and the rate of the transfer. The size is parsed like
+// ParsePrefix with opts, and the duration by time.ParseDuration. The rate is
+// exact, here 500 MB per 12s, rather than rounded to bytes per second. It
+// returns an error if the duration is not positive.
+func ParseThroughputPhrase(s string, opts ...ParseOption) (Bytes, time.Duration, Rate, error) {
+	n, end, err := ParsePrefix(s, opts...)
+	if err != nil {
+		return Bytes{}, 0, Rate{}, fmt.Errorf("invalid throughput %q: %w", s, err)
+	}
+	rest := strings.Fields(s[end:])
+	if len(rest) != 2 || !strings.EqualFold(rest[0], "in") {
+		return Bytes{}, 0, Rate{}, fmt.Errorf("invalid throughput %q: want \"<size> in <duration>\"", s)
+	}
+	d, err := time.ParseDuration(rest[1])
+	if err != nil {
+		return Bytes{}, 0, Rate{}, fmt.Errorf("invalid throughput %q: %w", s, err)
+	}
+	if d <= 0 {
+		return Bytes{}, 0, Rate{}, fmt.Errorf("invalid throughput %q: duration %v is not positive", s, d)
+	}
+	return n, d, Rate{Amount: n, Per: d}, nil
+}
+
+// bitsToBytes rewrites the unit of a size written in bits, such as "1.5 Mb",
+// "8 bits" or "2 megabits", as the byte unit with the same multiplier, so
+// that parsing the result counts bits. It returns s unchanged and false if
+// its unit is not in bits.
+func bitsToBytes(s string) (string, bool) {
+	i := strings.LastIndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) + 1
+	num, unit := s[:i], s[i:]
+	lower := strings.ToLower(unit)
+	switch {
+	case strings.HasSuffix(lower, "bits"):
+		return bitUnitToBytes(num, unit[:len(unit)-4], "bytes"), true
+	case strings.HasSuffix(lower, "bit"):
+		return bitUnitToBytes(num, unit[:len(unit)-3], "byte"), true
+	case strings.HasSuffix(unit, "b"):
+		return num + unit[:len(unit)-1] + "B", true
+	}
+	return s, false
+}
+
+// bitUnitToBytes returns num followed by the byte unit for prefix, which
+// is written out in full with long, as in "megabytes", if that is a unit,
+// and followed by "B", as in "MB", otherwise.
+func bitUnitToBytes(num, prefix, long string) string {
+	if _, ok := lookupUnit(prefix + long); ok {
+		return num + prefix + long
+	}
+	return num + prefix + "B"
+}
+
+// durationOf returns how long transferring n bytes takes at r, rounded up
+// to a whole nanosecond. It returns false if r is not positive or the
+// duration does not fit in a time.Duration.
+func (r Rate) durationOf(n Bytes) (time.Duration, bool) {
+	if Uint128(r.Amount).IsZero() || r.Per <= 0 {
+		return 0, false
+	}
+	ns, err := Uint128(n).Mul64Err(uint64(r.Per))
+	if err != nil {
+		return 0, false
+	}
+	q := ceilQuo(ns, Uint128(r.Amount))
+	if q.Cmp64(math.MaxInt64) > 0 {
+		return 0, false
+	}
+	return time.Duration(q.Lo), true
+}
+
+// Limiter paces transfers to a Rate. Each call to Wait reserves the time
+// its bytes take at the rate, after any earlier reservations, and blocks
+// until the earlier reservations have passed; so a transfer that waits
+// before writing each chunk averages at most the rate, with no burst
+// beyond one chunk. It is safe for concurrent use.
+type Limiter struct {
+	mu   sync.Mutex
+	rate Rate
+	next time.Time // when the reservations made so far have passed
+}
+
+// NewLimiter returns a Limiter pacing transfers to rate.
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{rate: rate}
+}
+
+// Rate returns the rate the Limiter paces to.
+func (l *Limiter) Rate() Rate {
+	return l.rate
+}
+
+// Wait reserves the time n bytes take at the Limiter's rate and blocks
+// until it may transfer them, or until ctx is done. It returns an error if
+// the rate is not positive, if n would take longer than the longest
+// time.Duration, or ctx's error if ctx is done first; the reservation is
+// kept in the last case.
+func (l *Limiter) Wait(ctx context.Context, n Bytes) error {
+	d, ok := l.rate.durationOf(n)
+	if !ok {
+		return fmt.Errorf("cannot pace %s at %s", n, l.rate)
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(d)
+	l.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
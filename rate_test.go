@@ -0,0 +1,204 @@
+package bytesize
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRateString tests formatting rates
+func TestRateString(t *testing.T) {
+	tests := []struct {
+		rate Rate
+		want string
+	}{
+		{PerSecond(Bytes{1_500_000, 0}), "1.50 MB/s"},
+		{Rate{GB, time.Minute}, "1.00 GB/min"},
+		{Rate{TB, time.Hour}, "1.00 TB/h"},
+		{Rate{KB, 10 * time.Millisecond}, "1.00 KB/10ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.rate.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseRate tests parsing rates with byte and bit units and periods
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Rate
+		wantErr bool
+	}{
+		{"1.50 MB/s", PerSecond(Bytes{1_500_000, 0}), false},
+		{"100 MBps", PerSecond(times(MB, 100)), false},
+		{"2 GiB/h", Rate{times(GiB, 2), time.Hour}, false},
+		{"1 GB / minute", Rate{GB, time.Minute}, false},
+		{"1.00 KB/10ms", Rate{KB, 10 * time.Millisecond}, false},
+		{"100 Mbps", PerSecond(Bytes{12_500_000, 0}), false},
+		{"100 Mb/s", PerSecond(Bytes{12_500_000, 0}), false},
+		{"100 Mbit/s", PerSecond(Bytes{12_500_000, 0}), false},
+		{"2 megabits/sec", PerSecond(Bytes{250_000, 0}), false},
+		{"1 Kbps", PerSecond(Bytes{125, 0}), false},
+		{"1 Kibps", PerSecond(Bytes{128, 0}), false},
+		{"8 bits/s", PerSecond(B), false},
+		{"1 bps", Rate{B, 8 * time.Second}, false},
+		{"12 b/min", Rate{Bytes{12, 0}, 8 * time.Minute}, false},
+		{"100 MB", Rate{}, true},
+		{"100 MB/fortnight", Rate{}, true},
+		{"100 XB/s", Rate{}, true},
+		{"1 b/2562047h", Rate{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRate(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRate(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRate(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRateFormatRoundTrip tests that ParseRate parses what Format writes
+func TestRateFormatRoundTrip(t *testing.T) {
+	tests := []struct {
+		rate Rate
+		opts []FormatOption
+		want string
+	}{
+		{PerSecond(times(MiB, 3)), []FormatOption{WithDecimalUnits(false)}, "3.00 MiB/s"},
+		{PerSecond(Bytes{12_500_000, 0}), []FormatOption{WithBitsOutput()}, "100.00 Mb/s"},
+		{Rate{B, 8 * time.Second}, []FormatOption{WithBitsOutput()}, "8.00 b/8s"},
+		{Rate{GB, time.Minute}, nil, "1.00 GB/min"},
+		{Rate{KB, 10 * time.Millisecond}, nil, "1.00 KB/10ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := tt.rate.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+			back, err := ParseRate(got)
+			if err != nil {
+				t.Fatalf("ParseRate(%q) error = %v", got, err)
+			}
+			if back != tt.rate {
+				t.Errorf("ParseRate(%q) = %v, want %v", got, back, tt.rate)
+			}
+		})
+	}
+}
+
+// TestParseThroughputPhrase tests parsing a size transferred in a duration
+func TestParseThroughputPhrase(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantN   Bytes
+		wantD   time.Duration
+		wantErr bool
+	}{
+		{"500 MB in 12s", times(MB, 500), 12 * time.Second, false},
+		{"1.5GiB in 1m30s", Bytes{1 << 30 * 3 / 2, 0}, 90 * time.Second, false},
+		{"  64 KiB IN 250ms ", times(KiB, 64), 250 * time.Millisecond, false},
+		{"500 MB", None, 0, true},
+		{"500 MB in", None, 0, true},
+		{"500 MB over 12s", None, 0, true},
+		{"500 MB in 12 s", None, 0, true},
+		{"500 MB in 0s", None, 0, true},
+		{"500 in 12s", None, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			n, d, r, err := ParseThroughputPhrase(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseThroughputPhrase(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if n != tt.wantN || d != tt.wantD {
+				t.Errorf("ParseThroughputPhrase(%q) = %v, %v, want %v, %v", tt.in, n, d, tt.wantN, tt.wantD)
+			}
+			if want := (Rate{tt.wantN, tt.wantD}); r != want {
+				t.Errorf("ParseThroughputPhrase(%q) rate = %v, want %v", tt.in, r, want)
+			}
+		})
+	}
+}
+
+// TestRateDurationOf tests how long transfers take at a rate
+func TestRateDurationOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   Rate
+		n      Bytes
+		want   time.Duration
+		wantOK bool
+	}{
+		{"whole", PerSecond(KB), Bytes{500, 0}, 500 * time.Millisecond, true},
+		{"rounded up", PerSecond(Bytes{3, 0}), B, 333333334 * time.Nanosecond, true},
+		{"nothing", PerSecond(KB), None, 0, true},
+		{"zero amount", PerSecond(None), B, 0, false},
+		{"zero period", Rate{KB, 0}, B, 0, false},
+		{"negative period", Rate{KB, -time.Second}, B, 0, false},
+		{"too long", PerSecond(B), Bytes{1 << 62, 0}, 0, false},
+		{"overflow", PerSecond(B), Bytes(Max), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rate.durationOf(tt.n)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("durationOf(%v) = %v, %v, want %v, %v", tt.n, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestLimiterWait tests pacing transfers with a Limiter
+func TestLimiterWait(t *testing.T) {
+	l := NewLimiter(Rate{KB, 100 * time.Millisecond})
+	if got := l.Rate(); got != (Rate{KB, 100 * time.Millisecond}) {
+		t.Errorf("Rate() = %v", got)
+	}
+
+	// The first 500 bytes pass at once, the next wait 50ms for them
+	start := time.Now()
+	for range 3 {
+		if err := l.Wait(context.Background(), Bytes{500, 0}); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("three waits of 500 bytes at 1 KB/100ms took %v, want at least 100ms", elapsed)
+	}
+}
+
+// TestLimiterWaitErrors tests Limiter.Wait with an invalid rate and a
+// cancelled context
+func TestLimiterWaitErrors(t *testing.T) {
+	if err := NewLimiter(PerSecond(None)).Wait(context.Background(), B); err == nil {
+		t.Error("Wait() at a zero rate returned no error")
+	}
+
+	l := NewLimiter(PerSecond(B))
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := l.Wait(ctx, Bytes{3600, 0}); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	cancel()
+	if err := l.Wait(ctx, B); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want context.Canceled", err)
+	}
+}
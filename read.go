@@ -0,0 +1,64 @@
+package bytesize
+
+import (
+	"bytes"
+	"io"
+	"math"
+)
+
+// readExactlyPrealloc is the largest n ReadExactly allocates up front.
+// Larger reads grow their buffer as data arrives, so that a length read
+// from untrusted input cannot allocate more memory than the reader
+// actually supplies.
+const readExactlyPrealloc = 64 << 10
+
+// ReadExactly reads exactly n bytes from r, like io.ReadFull with a buffer
+// of n bytes. It returns io.EOF if no bytes were read and
+// io.ErrUnexpectedEOF if fewer than n were, along with the bytes that were
+// read. It returns a *RangeError if n is more than a slice can hold.
+func ReadExactly(r io.Reader, n Bytes) ([]byte, error) {
+	if Uint128(n).Hi != 0 || Uint128(n).Lo > math.MaxInt {
+		return nil, &RangeError{Func: "ReadExactly", Value: Uint128(n).String()}
+	}
+	size := int(Uint128(n).Lo)
+	if size <= readExactlyPrealloc {
+		buf := make([]byte, size)
+		read, err := io.ReadFull(r, buf)
+		return buf[:read], err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(readExactlyPrealloc)
+	read, err := io.CopyN(&buf, r, int64(size))
+	return buf.Bytes(), shortReadError(read, err)
+}
+
+// DiscardExactly reads and discards exactly n bytes from r, such as to
+// skip a length-prefixed field. It returns io.EOF if no bytes were read and
+// io.ErrUnexpectedEOF if fewer than n were.
+func DiscardExactly(r io.Reader, n Bytes) error {
+	remaining := Uint128(n)
+	var read int64
+	for !remaining.IsZero() {
+		chunk := int64(math.MaxInt64)
+		if remaining.Cmp64(math.MaxInt64) < 0 {
+			chunk = int64(remaining.Lo)
+		}
+		copied, err := io.CopyN(io.Discard, r, chunk)
+		read += copied
+		if err != nil {
+			return shortReadError(read, err)
+		}
+		remaining = remaining.Sub64(uint64(copied))
+	}
+	return nil
+}
+
+// shortReadError returns the error io.ReadFull would for a read that
+// stopped with err after read bytes.
+func shortReadError(read int64, err error) error {
+	if err == io.EOF && read > 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
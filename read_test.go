@@ -0,0 +1,86 @@
+package bytesize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestReadExactly tests reading an exact number of bytes
+func TestReadExactly(t *testing.T) {
+	large := strings.Repeat("x", readExactlyPrealloc+10)
+	tests := []struct {
+		name    string
+		input   string
+		n       Bytes
+		want    string
+		wantErr error
+	}{
+		{"exact", "hello", Bytes{5, 0}, "hello", nil},
+		{"prefix", "hello world", Bytes{5, 0}, "hello", nil},
+		{"zero", "hello", None, "", nil},
+		{"empty", "", Bytes{5, 0}, "", io.EOF},
+		{"short", "hel", Bytes{5, 0}, "hel", io.ErrUnexpectedEOF},
+		{"large", large, Bytes{uint64(len(large)), 0}, large, nil},
+		{"large short", large, Bytes{uint64(len(large)) + 1, 0}, large, io.ErrUnexpectedEOF},
+		{"large empty", "", Bytes{readExactlyPrealloc + 1, 0}, "", io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadExactly(strings.NewReader(tt.input), tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadExactly() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ReadExactly() = %d bytes, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+// TestReadExactlyRange tests that sizes a slice cannot hold are rejected
+// without reading
+func TestReadExactlyRange(t *testing.T) {
+	r := strings.NewReader("hello")
+	if _, err := ReadExactly(r, Bytes{0, 1}); !errors.Is(err, ErrRange) {
+		t.Errorf("ReadExactly(2^64) error = %v, want ErrRange", err)
+	}
+	if r.Len() != 5 {
+		t.Errorf("ReadExactly(2^64) read %d bytes, want 0", 5-r.Len())
+	}
+}
+
+// TestDiscardExactly tests discarding an exact number of bytes
+func TestDiscardExactly(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		n        Bytes
+		wantRest string
+		wantErr  error
+	}{
+		{"prefix", "hello world", Bytes{6, 0}, "world", nil},
+		{"all", "hello", Bytes{5, 0}, "", nil},
+		{"zero", "hello", None, "hello", nil},
+		{"empty", "", Bytes{1, 0}, "", io.EOF},
+		{"short", "hello", Bytes{6, 0}, "", io.ErrUnexpectedEOF},
+		{"huge", "hello", Bytes{0, 1}, "", io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := strings.NewReader(tt.input)
+			err := DiscardExactly(r, tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DiscardExactly() error = %v, want %v", err, tt.wantErr)
+			}
+			rest, _ := io.ReadAll(r)
+			if !bytes.Equal(rest, []byte(tt.wantRest)) {
+				t.Errorf("DiscardExactly() left %q, want %q", rest, tt.wantRest)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// RedundancyScheme is a way of storing data redundantly, such as a RAID
+// level over a number of equal disks, N-way replication or erasure coding.
+// Create one with RAID0, RAID1, RAID5, RAID6, RAID10, Replication or
+// ErasureCoding.
+type RedundancyScheme struct {
+	kind redundancyKind
+	n, m int
+}
+
+type redundancyKind int
+
+const (
+	raid0 redundancyKind = iota + 1
+	raid1
+	raid5
+	raid6
+	raid10
+	replication
+	erasureCoding
+)
+
+// RAID0 stripes data over disks with no redundancy.
+func RAID0(disks int) RedundancyScheme { return RedundancyScheme{kind: raid0, n: disks} }
+
+// RAID1 mirrors data on every one of disks.
+func RAID1(disks int) RedundancyScheme { return RedundancyScheme{kind: raid1, n: disks} }
+
+// RAID5 stripes data over disks with one disk's worth of parity.
+func RAID5(disks int) RedundancyScheme { return RedundancyScheme{kind: raid5, n: disks} }
+
+// RAID6 stripes data over disks with two disks' worth of parity.
+func RAID6(disks int) RedundancyScheme { return RedundancyScheme{kind: raid6, n: disks} }
+
+// RAID10 stripes data over mirrored pairs of disks.
+func RAID10(disks int) RedundancyScheme { return RedundancyScheme{kind: raid10, n: disks} }
+
+// Replication stores n copies of the data.
+func Replication(n int) RedundancyScheme { return RedundancyScheme{kind: replication, n: n} }
+
+// ErasureCoding splits data into k data shards and m parity shards, any k
+// of which recover it, as in Reed-Solomon (k,m).
+func ErasureCoding(k, m int) RedundancyScheme {
+	return RedundancyScheme{kind: erasureCoding, n: k, m: m}
+}
+
+// String returns the scheme's name and parameters, e.g. "RAID5(4)" or
+// "EC(8,3)".
+func (s RedundancyScheme) String() string {
+	switch s.kind {
+	case raid0, raid1, raid5, raid6, raid10:
+		return fmt.Sprintf("RAID%d(%d)", [...]int{raid0: 0, raid1: 1, raid5: 5, raid6: 6, raid10: 10}[s.kind], s.n)
+	case replication:
+		return fmt.Sprintf("Replication(%d)", s.n)
+	case erasureCoding:
+		return fmt.Sprintf("EC(%d,%d)", s.n, s.m)
+	default:
+		return "RedundancyScheme(invalid)"
+	}
+}
+
+// redundancyMinimums are the fewest disks, copies or data shards each kind
+// of scheme needs, and zero for the zero RedundancyScheme.
+var redundancyMinimums = [...]int{raid0: 1, raid1: 2, raid5: 3, raid6: 4, raid10: 4, replication: 1, erasureCoding: 1}
+
+// efficiency returns the usable fraction of raw capacity as data/total, or
+// an error if the scheme's parameters are invalid.
+func (s RedundancyScheme) efficiency() (data, total int, err error) {
+	min := redundancyMinimums[s.kind]
+	switch {
+	case min == 0:
+		return 0, 0, fmt.Errorf("invalid redundancy scheme")
+	case s.n < min && s.kind == replication:
+		return 0, 0, fmt.Errorf("invalid %s: need at least 1 copy", s)
+	case s.n < min && s.kind == erasureCoding:
+		return 0, 0, fmt.Errorf("invalid %s: need at least 1 data shard", s)
+	case s.n < min:
+		return 0, 0, fmt.Errorf("invalid %s: need at least %d disks", s, min)
+	case s.kind == raid10 && s.n%2 != 0:
+		return 0, 0, fmt.Errorf("invalid %s: need an even number of disks", s)
+	case s.kind == erasureCoding && s.m < 0:
+		return 0, 0, fmt.Errorf("invalid %s: negative parity shard count", s)
+	}
+
+	switch s.kind {
+	case raid0:
+		return 1, 1, nil
+	case raid1, replication:
+		return 1, s.n, nil
+	case raid5:
+		return s.n - 1, s.n, nil
+	case raid6:
+		return s.n - 2, s.n, nil
+	case raid10:
+		return 1, 2, nil
+	default:
+		return s.n, s.n + s.m, nil
+	}
+}
+
+// UsableCapacity returns how much data fits in raw bytes of storage under
+// scheme, with any fraction of a byte truncated. For RAID levels, raw is
+// the combined size of equal disks. It returns an error if the scheme's
+// parameters are invalid, such as RAID5 on fewer than three disks.
+func UsableCapacity(raw Bytes, scheme RedundancyScheme) (Bytes, error) {
+	data, total, err := scheme.efficiency()
+	if err != nil {
+		return Bytes{}, err
+	}
+	if data == total {
+		return raw, nil
+	}
+	// raw × data overflows only for absurd sizes; divide first in that case
+	product, err := Uint128(raw).Mul64Err(uint64(data))
+	if err != nil {
+		// r × data < total × 2^64, so its quotient by total fits in 64 bits
+		q, r := Uint128(raw).QuoRem64(uint64(total))
+		hi, lo := bits.Mul64(r, uint64(data))
+		frac, _ := bits.Div64(hi, lo, uint64(total))
+		return Bytes(q.Mul64(uint64(data)).Add64(frac)), nil
+	}
+	q, _ := product.QuoRem64(uint64(total))
+	return Bytes(q), nil
+}
@@ -0,0 +1,61 @@
+package bytesize
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUsableCapacity tests usable capacity under redundancy schemes
+func TestUsableCapacity(t *testing.T) {
+	// Max is a multiple of 3, so two thirds of it is exact
+	third, _ := Max.QuoRem64(3)
+	tests := []struct {
+		scheme  RedundancyScheme
+		raw     Bytes
+		want    Bytes
+		wantErr string
+	}{
+		{RAID0(4), times(TB, 4), times(TB, 4), ""},
+		{RAID1(2), times(TB, 2), TB, ""},
+		{RAID1(3), times(TB, 3), TB, ""},
+		{RAID5(4), times(TB, 4), times(TB, 3), ""},
+		{RAID6(6), times(TB, 6), times(TB, 4), ""},
+		{RAID10(4), times(TB, 4), times(TB, 2), ""},
+		{Replication(3), times(TB, 3), TB, ""},
+		{Replication(1), TB, TB, ""},
+		{ErasureCoding(8, 3), times(TB, 11), times(TB, 8), ""},
+		{ErasureCoding(4, 0), TB, TB, ""},
+		{Replication(3), Bytes{10, 0}, Bytes{3, 0}, ""},
+		{RAID5(3), Bytes(Max), Bytes(third.Mul64(2)), ""},
+		// Enough shards that the remainder times the data shards overflows
+		// 64 bits: three quarters of Max is 3 × 2^126 - 1 rounded down
+		{ErasureCoding(3<<40, 1<<40), Bytes(Max), Bytes{^uint64(0), 0xBFFF_FFFF_FFFF_FFFF}, ""},
+		{RAID0(0), TB, None, "invalid RAID0(0): need at least 1 disks"},
+		{RAID1(1), TB, None, "invalid RAID1(1): need at least 2 disks"},
+		{RAID5(2), TB, None, "invalid RAID5(2): need at least 3 disks"},
+		{RAID6(3), TB, None, "invalid RAID6(3): need at least 4 disks"},
+		{RAID10(5), TB, None, "invalid RAID10(5): need an even number of disks"},
+		{Replication(0), TB, None, "invalid Replication(0): need at least 1 copy"},
+		{ErasureCoding(0, 2), TB, None, "invalid EC(0,2): need at least 1 data shard"},
+		{ErasureCoding(4, -1), TB, None, "invalid EC(4,-1): negative parity shard count"},
+		{RedundancyScheme{}, TB, None, "invalid redundancy scheme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme.String(), func(t *testing.T) {
+			got, err := UsableCapacity(tt.raw, tt.scheme)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UsableCapacity() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UsableCapacity() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UsableCapacity(%v, %v) = %v, want %v", Uint128(tt.raw), tt.scheme, Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package bytesize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// RoundingMode selects how a value that falls between two representable
+// values is rounded: a parsed size between two whole bytes, a formatted
+// value between two decimals, a product from MulFloat64 or a size rounded
+// with RoundToUnit. Sizes are never negative, so RoundFloor and
+// RoundTruncate agree.
+type RoundingMode int
+
+const (
+	// RoundTruncate discards the fraction. This is the default for Parse.
+	RoundTruncate RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, rounding halves up.
+	RoundHalfUp
+	// RoundCeil rounds any fraction up.
+	RoundCeil
+	// RoundFloor rounds any fraction down, which for sizes is the same as
+	// RoundTruncate.
+	RoundFloor
+	// RoundHalfEven rounds to the nearest value, rounding halves to the
+	// even neighbor. This is the default for Format.
+	RoundHalfEven
+)
+
+// String returns the name of the rounding mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundTruncate:
+		return "truncate"
+	case RoundHalfUp:
+		return "half-up"
+	case RoundCeil:
+		return "ceil"
+	case RoundFloor:
+		return "floor"
+	case RoundHalfEven:
+		return "half-even"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// valid reports whether m is one of the defined rounding modes.
+func (m RoundingMode) valid() bool {
+	return m >= RoundTruncate && m <= RoundHalfEven
+}
+
+// roundUp reports whether a non-negative value with a non-zero fraction
+// should be rounded up under m. half is the comparison of the fraction
+// with one half, -1, 0 or 1, and odd reports whether the value rounded
+// down is odd.
+func (m RoundingMode) roundUp(half int, odd bool) bool {
+	switch m {
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && odd)
+	case RoundCeil:
+		return true
+	default:
+		return false
+	}
+}
+
+// roundsUp reports whether the non-negative quotient quo with the non-zero
+// remainder rem of a division by den should be rounded up under m. scratch
+// is overwritten.
+func (m RoundingMode) roundsUp(quo, rem, den, scratch *big.Int) bool {
+	return m.roundUp(scratch.Lsh(rem, 1).Cmp(den), quo.Bit(0) == 1)
+}
+
+// roundRat returns r, which must not be negative, rounded to a whole
+// number under m, or an error if the result overflows.
+func roundRat(r *big.Rat, m RoundingMode) (Bytes, error) {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() != 0 && m.roundsUp(quo, rem, r.Denom(), new(big.Int)) {
+		quo.Add(quo, bigOne)
+	}
+	u, err := FromBigErr(quo)
+	if err != nil {
+		return Bytes{}, fmt.Errorf("value overflows Uint128: result is %d bits", quo.BitLen())
+	}
+	return Bytes(u), nil
+}
+
+// MulFloat64 returns b × f rounded to a whole byte under mode, such as a
+// size scaled by a ratio from configuration. The product is exact before it
+// is rounded. It returns an error if f is negative, NaN or infinite, if
+// mode is invalid, or if the result overflows.
+func (b Bytes) MulFloat64(f float64, mode RoundingMode) (Bytes, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < 0 {
+		return Bytes{}, fmt.Errorf("invalid factor: %v", f)
+	}
+	if !mode.valid() {
+		return Bytes{}, fmt.Errorf("invalid rounding mode: %d", mode)
+	}
+	r := new(big.Rat).SetFloat64(f)
+	return roundRat(r.Mul(r, new(big.Rat).SetInt(Uint128(b).Big())), mode)
+}
+
+// RoundToUnit returns b rounded to a whole multiple of unit under mode,
+// such as to whole MiB or to pages. It returns an error if unit is zero,
+// if mode is invalid, or if the result overflows.
+func (b Bytes) RoundToUnit(unit Bytes, mode RoundingMode) (Bytes, error) {
+	if Uint128(unit).IsZero() {
+		return Bytes{}, fmt.Errorf("invalid unit: zero")
+	}
+	if !mode.valid() {
+		return Bytes{}, fmt.Errorf("invalid rounding mode: %d", mode)
+	}
+	q, r := Uint128(b).QuoRemBytes(unit)
+	if r.IsZero() {
+		return b, nil
+	}
+	// Compare r with half of unit without overflowing: 2r against unit
+	half := 1
+	if r.Cmp(Uint128(unit).Sub(r)) < 0 {
+		half = -1
+	} else if r.Cmp(Uint128(unit).Sub(r)) == 0 {
+		half = 0
+	}
+	if mode.roundUp(half, q.Lo&1 == 1) {
+		q = q.AddWrap64(1)
+		if q.IsZero() {
+			return Bytes{}, fmt.Errorf("value overflows Uint128")
+		}
+	}
+	result, err := q.MulBytesErr(unit)
+	if err != nil {
+		return Bytes{}, fmt.Errorf("value overflows Uint128")
+	}
+	return Bytes(result), nil
+}
@@ -0,0 +1,229 @@
+package bytesize
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestParseExactRounding tests ParseExact with each rounding mode
+func TestParseExactRounding(t *testing.T) {
+	tests := []struct {
+		input     string
+		mode      RoundingMode
+		want      Bytes
+		wantExact bool
+	}{
+		{"0.00001 KB", RoundTruncate, Bytes{0, 0}, false},
+		{"0.00001 KB", RoundHalfUp, Bytes{0, 0}, false},
+		{"0.00001 KB", RoundCeil, Bytes{1, 0}, false},
+		{"0.0005 KB", RoundTruncate, Bytes{0, 0}, false},
+		{"0.0005 KB", RoundHalfUp, Bytes{1, 0}, false},
+		{"0.0005 KB", RoundCeil, Bytes{1, 0}, false},
+		{"0.0004 KB", RoundHalfUp, Bytes{0, 0}, false},
+		{"3.14159 KB", RoundTruncate, Bytes{3141, 0}, false},
+		{"3.14159 KB", RoundHalfUp, Bytes{3142, 0}, false},
+		{"3.14159 KB", RoundCeil, Bytes{3142, 0}, false},
+		{"3.14159 KB", RoundFloor, Bytes{3141, 0}, false},
+		{"3.14159 KB", RoundHalfEven, Bytes{3142, 0}, false},
+		{"0.0005 KB", RoundHalfEven, Bytes{0, 0}, false},
+		{"0.0015 KB", RoundHalfEven, Bytes{2, 0}, false},
+		{"1.5 KiB", RoundCeil, Bytes{1536, 0}, true},
+		{"10 MB", RoundHalfUp, Bytes{10_000_000, 0}, true},
+		{"0 B", RoundCeil, Bytes{0, 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input+"/"+tt.mode.String(), func(t *testing.T) {
+			got, exact, err := ParseExact(tt.input, WithFractionalRounding(tt.mode))
+			if err != nil {
+				t.Fatalf("ParseExact(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want || exact != tt.wantExact {
+				t.Errorf("ParseExact(%q, %s) = (%v, %v), want (%v, %v)",
+					tt.input, tt.mode, Uint128(got), exact, Uint128(tt.want), tt.wantExact)
+			}
+		})
+	}
+}
+
+// TestParseRoundingOverflow tests that rounding up past the largest value
+// is reported as an overflow
+func TestParseRoundingOverflow(t *testing.T) {
+	input := "340282366920938463463374607431768211455.5 B"
+	if _, err := Parse(input); err != nil {
+		t.Fatalf("Parse(%q) error = %v, want nil", input, err)
+	}
+	if _, err := Parse(input, WithFractionalRounding(RoundCeil)); err == nil {
+		t.Errorf("Parse(%q, RoundCeil) should have overflowed", input)
+	}
+}
+
+// TestWithFractionalRoundingInvalid tests that unknown rounding modes are
+// rejected
+func TestWithFractionalRoundingInvalid(t *testing.T) {
+	if _, err := Parse("1 B", WithFractionalRounding(RoundingMode(42))); err == nil {
+		t.Errorf("Parse() with RoundingMode(42) should have errored")
+	}
+}
+
+// TestRoundingModeString tests the names of the rounding modes
+func TestRoundingModeString(t *testing.T) {
+	tests := map[RoundingMode]string{
+		RoundTruncate:    "truncate",
+		RoundHalfUp:      "half-up",
+		RoundCeil:        "ceil",
+		RoundFloor:       "floor",
+		RoundHalfEven:    "half-even",
+		RoundingMode(42): "RoundingMode(42)",
+	}
+	for mode, want := range tests {
+		if got := mode.String(); got != want {
+			t.Errorf("RoundingMode(%d).String() = %q, want %q", int(mode), got, want)
+		}
+	}
+}
+
+// TestMulFloat64 tests scaling sizes by a factor under each rounding mode
+func TestMulFloat64(t *testing.T) {
+	tests := []struct {
+		b    Bytes
+		f    float64
+		mode RoundingMode
+		want Bytes
+	}{
+		{KB, 1.5, RoundTruncate, Bytes{1500, 0}},
+		{Bytes{5, 0}, 0.5, RoundTruncate, Bytes{2, 0}},
+		{Bytes{5, 0}, 0.5, RoundFloor, Bytes{2, 0}},
+		{Bytes{5, 0}, 0.5, RoundCeil, Bytes{3, 0}},
+		{Bytes{5, 0}, 0.5, RoundHalfUp, Bytes{3, 0}},
+		{Bytes{5, 0}, 0.5, RoundHalfEven, Bytes{2, 0}},
+		{Bytes{7, 0}, 0.5, RoundHalfEven, Bytes{4, 0}},
+		{Bytes{10, 0}, 0.25, RoundHalfUp, Bytes{3, 0}},
+		{Bytes{10, 0}, 0.2, RoundCeil, Bytes{3, 0}}, // 0.2 is slightly more than 1/5
+		{GiB, 0, RoundCeil, Bytes{0, 0}},
+		{EB, 1000, RoundTruncate, ZB},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v×%v/%s", Uint128(tt.b), tt.f, tt.mode), func(t *testing.T) {
+			got, err := tt.b.MulFloat64(tt.f, tt.mode)
+			if err != nil {
+				t.Fatalf("MulFloat64() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MulFloat64() = %v, want %v", Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestMulFloat64Errors tests that invalid factors, invalid modes and
+// overflows are rejected
+func TestMulFloat64Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Bytes
+		f    float64
+		mode RoundingMode
+	}{
+		{"negative", KB, -1, RoundTruncate},
+		{"NaN", KB, math.NaN(), RoundTruncate},
+		{"infinite", KB, math.Inf(1), RoundTruncate},
+		{"invalid mode", KB, 1, RoundingMode(42)},
+		{"overflow", Bytes(Max), 2, RoundTruncate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.b.MulFloat64(tt.f, tt.mode); err == nil {
+				t.Errorf("MulFloat64(%v, %s) should have errored", tt.f, tt.mode)
+			}
+		})
+	}
+}
+
+// TestRoundToUnit tests rounding sizes to whole units under each rounding
+// mode
+func TestRoundToUnit(t *testing.T) {
+	tests := []struct {
+		b    Bytes
+		unit Bytes
+		mode RoundingMode
+		want Bytes
+	}{
+		{Bytes{1500, 0}, KB, RoundTruncate, KB},
+		{Bytes{1500, 0}, KB, RoundFloor, KB},
+		{Bytes{1500, 0}, KB, RoundCeil, Bytes{2000, 0}},
+		{Bytes{1500, 0}, KB, RoundHalfUp, Bytes{2000, 0}},
+		{Bytes{1500, 0}, KB, RoundHalfEven, Bytes{2000, 0}},
+		{Bytes{2500, 0}, KB, RoundHalfEven, Bytes{2000, 0}},
+		{Bytes{2499, 0}, KB, RoundHalfUp, Bytes{2000, 0}},
+		{Bytes{1, 0}, KiB, RoundCeil, KiB},
+		{Bytes{3, 0}, Bytes{3, 0}, RoundCeil, Bytes{3, 0}},
+		{Bytes{0, 0}, MiB, RoundCeil, Bytes{0, 0}},
+		{Bytes{7, 0}, Bytes{3, 0}, RoundHalfUp, Bytes{6, 0}},
+		{Bytes{8, 0}, Bytes{3, 0}, RoundHalfUp, Bytes{9, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v/%s", Uint128(tt.b), Uint128(tt.unit), tt.mode), func(t *testing.T) {
+			got, err := tt.b.RoundToUnit(tt.unit, tt.mode)
+			if err != nil {
+				t.Fatalf("RoundToUnit() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RoundToUnit() = %v, want %v", Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
+
+// TestRoundToUnitErrors tests that zero units, invalid modes and overflows
+// are rejected
+func TestRoundToUnitErrors(t *testing.T) {
+	if _, err := KB.RoundToUnit(Bytes{}, RoundCeil); err == nil {
+		t.Errorf("RoundToUnit(0) should have errored")
+	}
+	if _, err := KB.RoundToUnit(KiB, RoundingMode(42)); err == nil {
+		t.Errorf("RoundToUnit() with RoundingMode(42) should have errored")
+	}
+	if _, err := Bytes(Max).RoundToUnit(KiB, RoundCeil); err == nil {
+		t.Errorf("RoundToUnit(Max, KiB, RoundCeil) should have overflowed")
+	}
+}
+
+// TestFormatRounding tests formatting with each rounding mode
+func TestFormatRounding(t *testing.T) {
+	tests := []struct {
+		b    Bytes
+		opts []FormatOption
+		want string
+	}{
+		{Bytes{1_001_000_000, 0}, []FormatOption{WithFormatRounding(RoundCeil)}, "1.01 GB"},
+		{Bytes{1_009_000_000, 0}, []FormatOption{WithFormatRounding(RoundFloor)}, "1.00 GB"},
+		{Bytes{1_005_000_000, 0}, []FormatOption{WithFormatRounding(RoundHalfUp)}, "1.01 GB"},
+		{Bytes{1_005_000_000, 0}, []FormatOption{WithFormatRounding(RoundHalfEven)}, "1.00 GB"},
+		{Bytes{1_005_000_000, 0}, nil, "1.00 GB"},
+		{Bytes{1_000_000_000, 0}, []FormatOption{WithFormatRounding(RoundCeil)}, "1.00 GB"},
+		{Bytes{1_001_000_000, 0}, []FormatOption{WithFormatRounding(RoundCeil), WithSignificantDigits(2)}, "1.1 GB"},
+		{Bytes{9_991_000_000, 0}, []FormatOption{WithFormatRounding(RoundCeil), WithSignificantDigits(3)}, "10.0 GB"},
+		{Bytes(Max), []FormatOption{WithFormatRounding(RoundCeil)}, "340282366.93 QB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := tt.b.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := KB.Format(WithFormatRounding(RoundingMode(42))); err == nil {
+		t.Errorf("Format() with RoundingMode(42) should have errored")
+	}
+}
@@ -0,0 +1,50 @@
+package bytesize
+
+import "iter"
+
+// ScaleOrder is the order in which ScalesSI and ScalesIEC yield units.
+type ScaleOrder int
+
+const (
+	// Ascending yields units from the byte up.
+	Ascending ScaleOrder = iota
+	// Descending yields units from the largest down to the byte.
+	Descending
+)
+
+// ScalesSI returns an iterator over the SI units, starting with the byte,
+// in the given order.
+func ScalesSI(order ScaleOrder) iter.Seq[Unit] {
+	return scales(SI, order)
+}
+
+// ScalesIEC returns an iterator over the IEC units, starting with the byte,
+// in the given order.
+func ScalesIEC(order ScaleOrder) iter.Seq[Unit] {
+	return scales(IEC, order)
+}
+
+func scales(system UnitSystem, order ScaleOrder) iter.Seq[Unit] {
+	return func(yield func(Unit) bool) {
+		b := byteUnit
+		b.System = system
+		units := unitsOf(system)
+		if order == Descending {
+			for i := len(units) - 1; i >= 0; i-- {
+				if !yield(units[i]) {
+					return
+				}
+			}
+			yield(b)
+			return
+		}
+		if !yield(b) {
+			return
+		}
+		for _, u := range units {
+			if !yield(u) {
+				return
+			}
+		}
+	}
+}
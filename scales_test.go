@@ -0,0 +1,56 @@
+package bytesize
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestScales tests iterating over unit scales in both orders
+func TestScales(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []Unit
+		want []Unit
+	}{
+		{"SI ascending", slices.Collect(ScalesSI(Ascending)), ListUnits(SI)},
+		{"IEC ascending", slices.Collect(ScalesIEC(Ascending)), ListUnits(IEC)},
+		{"SI descending", slices.Collect(ScalesSI(Descending)), reversed(ListUnits(SI))},
+		{"IEC descending", slices.Collect(ScalesIEC(Descending)), reversed(ListUnits(IEC))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestScalesBreak tests that the iterators stop when the loop breaks
+func TestScalesBreak(t *testing.T) {
+	for _, order := range []ScaleOrder{Ascending, Descending} {
+		var got []Unit
+		for u := range ScalesIEC(order) {
+			got = append(got, u)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if len(got) != 2 {
+			t.Errorf("ScalesIEC(%d) yielded %d units before break, want 2", order, len(got))
+		}
+	}
+
+	for u := range ScalesSI(Descending) {
+		if u.Factor != QB {
+			t.Errorf("ScalesSI(Descending) starts with %v, want QB", u)
+		}
+		break
+	}
+}
+
+func reversed(units []Unit) []Unit {
+	slices.Reverse(units)
+	return units
+}
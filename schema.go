@@ -0,0 +1,92 @@
+package bytesize
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Schema is a JSON Schema fragment describing the size strings Parse
+// accepts with its default options. Its fields marshal with encoding/json
+// in a fixed order, so the generated documentation is deterministic.
+type Schema struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Pattern     string   `json:"pattern"`
+	Examples    []string `json:"examples"`
+}
+
+// JSONSchema returns a JSON Schema fragment for Bytes fields encoded as
+// strings, for API authors to publish in OpenAPI documents. The pattern is
+// generated from the units Parse accepts, so it stays in step with the
+// parser. It is written in the ECMA 262 dialect JSON Schema uses, spells
+// out case insensitivity, and does not check that a size fits in 128 bits.
+func JSONSchema() Schema {
+	return Schema{
+		Type:        "string",
+		Description: `A byte size: a non-negative decimal number and a case-insensitive SI or IEC unit, e.g. "1.5 GB" or "512MiB".`,
+		Pattern:     sizePattern(`\s`),
+		Examples:    []string{"1.5 GB", "512MiB", "10 kilobytes", "0 B"},
+	}
+}
+
+// Grammar is the grammar of the size strings Parse accepts with its default
+// options, in the EBNF notation of the Go specification with comments in
+// (* and *). Tests keep it in step with the parser.
+const Grammar = `size     = { space } ( [ "+" ] number | "-" zero ) { space } unit { space } .
+number   = digits [ "." [ digits ] ] | "." digits .
+zero     = zeros [ "." [ zeros ] ] | "." zeros .
+digits   = digit { digit } .
+zeros    = "0" { "0" } .
+digit    = "0" … "9" .
+space    = (* a Unicode white space character *) .
+unit     = (* case insensitive *)
+           "b" | "kb" | "mb" | "gb" | "tb" | "pb"
+         | "eb" | "zb" | "yb" | "rb" | "qb"
+         | "kib" | "mib" | "gib" | "tib" | "pib" | "eib"
+         | "zib" | "yib" | "rib" | "qib"
+         | "byte" | "bytes"
+         | "kilobyte" | "kilobytes" | "megabyte" | "megabytes" | "gigabyte" | "gigabytes"
+         | "terabyte" | "terabytes" | "petabyte" | "petabytes" | "exabyte" | "exabytes"
+         | "zettabyte" | "zettabytes" | "yottabyte" | "yottabytes" | "ronnabyte" | "ronnabytes"
+         | "quettabyte" | "quettabytes"
+         | "kibibyte" | "kibibytes" | "mebibyte" | "mebibytes" | "gibibyte" | "gibibytes"
+         | "tebibyte" | "tebibytes" | "pebibyte" | "pebibytes" | "exbibyte" | "exbibytes"
+         | "zebibyte" | "zebibytes" | "yobibyte" | "yobibytes" | "ronnibyte" | "ronnibytes"
+         | "quettibyte" | "quettibytes" .
+`
+
+// acceptedPattern is compiled on first use.
+var acceptedPattern = sync.OnceValue(func() *regexp.Regexp {
+	return regexp.MustCompile(sizePattern(`[\t\n\v\f\r\x{85}\p{Z}]`))
+})
+
+// AcceptedPattern returns a regular expression matching exactly the size
+// strings Parse accepts with its default options, as described by Grammar,
+// for frontends that pre-validate input. The Schema pattern from JSONSchema
+// is the same expression in the ECMA 262 dialect. Neither checks that a
+// size fits in 128 bits.
+func AcceptedPattern() *regexp.Regexp {
+	return acceptedPattern()
+}
+
+// sizePattern returns a regular expression for Grammar, with space as the
+// character class of white space.
+func sizePattern(space string) string {
+	var sb strings.Builder
+	sb.WriteString(`^` + space + `*(\+?(\d+\.?\d*|\.\d+)|-(0+\.?0*|\.0+))` + space + `*(`)
+	for i, unit := range ValidUnits {
+		if i > 0 {
+			sb.WriteByte('|')
+		}
+		for _, r := range unit {
+			sb.WriteByte('[')
+			sb.WriteRune(unicode.ToLower(r))
+			sb.WriteRune(unicode.ToUpper(r))
+			sb.WriteByte(']')
+		}
+	}
+	sb.WriteString(`)` + space + `*$`)
+	return sb.String()
+}
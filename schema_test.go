@@ -0,0 +1,82 @@
+package bytesize
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// patternInputs are inputs that Parse accepts or rejects, for checking
+// that the patterns agree with it.
+var patternInputs = []string{
+	"1.5 GB", "512MiB", "10 kilobytes", "0 B", ".5 KB", "5. KB", "+1 KB",
+	" 1 KB ", "1\tkb", "1 KIB", "1 Bytes", "1 QuettiByte",
+	"-0 B", "-.0 B", "-0. KB", "-00 KB",
+	"", "1", "GB", "-1 KB", "1e3 KB", "1_000 KB", "1/2 KB", "0x10 KB",
+	"1 KBB", "1 k", "1 ki", "1 GB 2", ". KB", "1..2 KB", "1 megabyte s",
+	"- 0 B", "-0.1 B", "+-0 B", "-+0 B",
+}
+
+// TestJSONSchemaPattern tests that the schema pattern accepts exactly what
+// Parse accepts
+func TestJSONSchemaPattern(t *testing.T) {
+	pattern := regexp.MustCompile(JSONSchema().Pattern)
+	for _, input := range patternInputs {
+		_, err := Parse(input)
+		if got, want := pattern.MatchString(input), err == nil; got != want {
+			t.Errorf("pattern matches %q = %v, but Parse error = %v", input, got, err)
+		}
+	}
+	for _, example := range JSONSchema().Examples {
+		if _, err := Parse(example); err != nil || !pattern.MatchString(example) {
+			t.Errorf("example %q: Parse error = %v, pattern matches = %v", example, err, pattern.MatchString(example))
+		}
+	}
+}
+
+// TestAcceptedPattern tests that AcceptedPattern accepts exactly what
+// Parse accepts, including Unicode white space
+func TestAcceptedPattern(t *testing.T) {
+	inputs := append(slices.Clone(patternInputs), "1\u00a0KB", "1\u2028KB", "1\vKB", "1\u0085KB", "1\u200bKB")
+	for _, input := range inputs {
+		_, err := Parse(input)
+		if got, want := AcceptedPattern().MatchString(input), err == nil; got != want {
+			t.Errorf("AcceptedPattern() matches %q = %v, but Parse error = %v", input, got, err)
+		}
+	}
+}
+
+// TestGrammarUnits tests that Grammar lists the units Parse accepts
+func TestGrammarUnits(t *testing.T) {
+	_, production, ok := strings.Cut(Grammar, "unit     =")
+	if !ok {
+		t.Fatal("Grammar has no unit production")
+	}
+	var units []string
+	for _, m := range regexp.MustCompile(`"([a-z]+)"`).FindAllStringSubmatch(production, -1) {
+		units = append(units, m[1])
+	}
+	if !slices.Equal(units, ValidUnits) {
+		t.Errorf("Grammar units = %q, want ValidUnits %q", units, ValidUnits)
+	}
+}
+
+// TestJSONSchemaDeterministic tests that the schema marshals the same way
+// every time, with its fields in order
+func TestJSONSchemaDeterministic(t *testing.T) {
+	first, err := json.Marshal(JSONSchema())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for range 10 {
+		again, _ := json.Marshal(JSONSchema())
+		if string(again) != string(first) {
+			t.Fatalf("Marshal() = %s, then %s", first, again)
+		}
+	}
+	if !strings.HasPrefix(string(first), `{"type":"string","description":`) {
+		t.Errorf("Marshal() = %s, want type and description first", first)
+	}
+}
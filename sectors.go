@@ -0,0 +1,36 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Sector512 is the size of a 512-byte disk sector, the unit of LBA counts
+// in most storage tools.
+var Sector512 = Bytes{512, 0}
+
+// Sectors512 returns b as a count of 512-byte sectors. It returns an error
+// if b is not a whole number of sectors, and a *RangeError if the count
+// does not fit in a uint64.
+func Sectors512(b Bytes) (uint64, error) {
+	q, r := Uint128(b).QuoRem64(512)
+	if r != 0 {
+		return 0, fmt.Errorf("%s is not a whole number of 512-byte sectors", Uint128(b))
+	}
+	if q.Hi != 0 {
+		return 0, &RangeError{Func: "Sectors512", Value: Uint128(b).String()}
+	}
+	return q.Lo, nil
+}
+
+// FromSectors returns the size of n sectors of sectorSize bytes, e.g. of
+// Sector512 or of a 4 KiB native sector. It returns a *RangeError if the
+// size overflows, which needs a sector of more than 2^64 bytes.
+func FromSectors(n uint64, sectorSize Bytes) (Bytes, error) {
+	b, err := Uint128(sectorSize).Mul64Err(n)
+	if err != nil {
+		v := new(big.Int).Mul(Uint128(sectorSize).Big(), new(big.Int).SetUint64(n))
+		return Bytes{}, &RangeError{Func: "FromSectors", Value: v.String()}
+	}
+	return Bytes(b), nil
+}
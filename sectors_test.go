@@ -0,0 +1,69 @@
+package bytesize
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestSectors512 tests converting sizes to 512-byte sector counts
+func TestSectors512(t *testing.T) {
+	// The largest whole number of sectors that fits in a uint64
+	largest := Bytes(Uint128(Sector512).Mul64(math.MaxUint64))
+
+	tests := []struct {
+		name      string
+		b         Bytes
+		want      uint64
+		wantErr   bool
+		wantRange bool
+	}{
+		{"zero", None, 0, false, false},
+		{"one sector", Sector512, 1, false, false},
+		{"gibibyte", GiB, 2 << 20, false, false},
+		{"largest", largest, math.MaxUint64, false, false},
+		{"partial sector", Bytes{513, 0}, 0, true, false},
+		{"too many", Bytes(Uint128(largest).AddBytes(Sector512)), 0, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sectors512(tt.b)
+			if (err != nil) != tt.wantErr || errors.Is(err, ErrRange) != tt.wantRange {
+				t.Fatalf("Sectors512(%v) error = %v, wantErr %v, wantRange %v", Uint128(tt.b), err, tt.wantErr, tt.wantRange)
+			}
+			if got != tt.want {
+				t.Errorf("Sectors512(%v) = %d, want %d", Uint128(tt.b), got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFromSectors tests converting sector counts to sizes
+func TestFromSectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		n          uint64
+		sectorSize Bytes
+		want       Bytes
+		wantErr    bool
+	}{
+		{"512-byte sectors", 2048, Sector512, MiB, false},
+		{"4 KiB sectors", 256, times(KiB, 4), MiB, false},
+		{"no sectors", 0, Sector512, None, false},
+		{"largest", math.MaxUint64, Bytes{0, 1}, Bytes{0, math.MaxUint64}, false},
+		{"overflow", 2, Bytes{0, 1 << 63}, None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromSectors(tt.n, tt.sectorSize)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrRange)) {
+				t.Fatalf("FromSectors(%d, %v) error = %v, wantErr %v", tt.n, Uint128(tt.sectorSize), err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FromSectors(%d, %v) = %v, want %v", tt.n, Uint128(tt.sectorSize), Uint128(got), Uint128(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package bytesize
+
+import "sync"
+
+// SizeTracker does the size accounting of a cache bounded in bytes rather
+// than in entries: it records the size of each key and reports how much
+// must be evicted to admit another, leaving the choice of what to evict,
+// such as the least recently used entries, to the cache. It is safe for
+// concurrent use.
+type SizeTracker struct {
+	mu       sync.Mutex
+	capacity Bytes
+	used     Bytes
+	sizes    map[string]Bytes
+}
+
+// NewSizeTracker returns a SizeTracker for a cache holding up to capacity
+// bytes.
+func NewSizeTracker(capacity Bytes) *SizeTracker {
+	return &SizeTracker{capacity: capacity, sizes: make(map[string]Bytes)}
+}
+
+// Admit records key as holding size bytes, replacing its previous size if
+// it is already tracked, and returns how many bytes the cache must evict
+// with Remove to be back within its capacity, which is zero if it still
+// fits. If size alone is more than the capacity, the entry can never fit:
+// nothing is recorded and Admit returns false. It also returns false,
+// recording nothing, if the total size would overflow, which can only
+// happen when the capacity is near Max and evictions are outstanding.
+func (t *SizeTracker) Admit(key string, size Bytes) (evictNeeded Bytes, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if Uint128(size).CmpBytes(t.capacity) > 0 {
+		return Bytes{}, false
+	}
+	used, err := Uint128(t.used).SubBytes(t.sizes[key]).AddBytesErr(size)
+	if err != nil {
+		return Bytes{}, false
+	}
+	t.sizes[key] = size
+	t.used = Bytes(used)
+	if Uint128(t.used).CmpBytes(t.capacity) <= 0 {
+		return Bytes{}, true
+	}
+	return Bytes(Uint128(t.used).SubBytes(t.capacity)), true
+}
+
+// Remove stops tracking key and returns the size it held, or zero if it
+// was not tracked.
+func (t *SizeTracker) Remove(key string) Bytes {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	size, ok := t.sizes[key]
+	if !ok {
+		return Bytes{}
+	}
+	delete(t.sizes, key)
+	t.used = Bytes(Uint128(t.used).SubBytes(size))
+	return size
+}
+
+// Size returns the size recorded for key, and whether it is tracked.
+func (t *SizeTracker) Size(key string) (Bytes, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	size, ok := t.sizes[key]
+	return size, ok
+}
+
+// Len returns the number of keys tracked.
+func (t *SizeTracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.sizes)
+}
+
+// Used returns the total size of the keys tracked, which exceeds the
+// capacity after an Admit until enough keys are removed.
+func (t *SizeTracker) Used() Bytes {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.used
+}
+
+// Capacity returns the capacity of the cache.
+func (t *SizeTracker) Capacity() Bytes {
+	return t.capacity
+}
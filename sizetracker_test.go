@@ -0,0 +1,93 @@
+package bytesize
+
+import "testing"
+
+// TestSizeTrackerAdmit tests admitting entries and the evictions they need
+func TestSizeTrackerAdmit(t *testing.T) {
+	tr := NewSizeTracker(Bytes{100, 0})
+
+	steps := []struct {
+		key       string
+		size      Bytes
+		wantEvict Bytes
+		wantOK    bool
+		wantUsed  Bytes
+	}{
+		{"a", Bytes{40, 0}, None, true, Bytes{40, 0}},
+		{"b", Bytes{60, 0}, None, true, Bytes{100, 0}},
+		{"c", Bytes{30, 0}, Bytes{30, 0}, true, Bytes{130, 0}},
+		{"huge", Bytes{101, 0}, None, false, Bytes{130, 0}},
+		{"a", Bytes{10, 0}, None, true, Bytes{100, 0}},
+		{"a", Bytes{25, 0}, Bytes{15, 0}, true, Bytes{115, 0}},
+	}
+
+	for _, s := range steps {
+		evict, ok := tr.Admit(s.key, s.size)
+		if evict != s.wantEvict || ok != s.wantOK {
+			t.Errorf("Admit(%q, %v) = (%v, %v), want (%v, %v)", s.key, Uint128(s.size), Uint128(evict), ok, Uint128(s.wantEvict), s.wantOK)
+		}
+		if got := tr.Used(); got != s.wantUsed {
+			t.Errorf("Used() after Admit(%q, %v) = %v, want %v", s.key, Uint128(s.size), Uint128(got), Uint128(s.wantUsed))
+		}
+	}
+
+	if _, ok := tr.Size("huge"); ok {
+		t.Errorf("Size(huge) found an entry that did not fit")
+	}
+	if got, ok := tr.Size("a"); !ok || got != (Bytes{25, 0}) {
+		t.Errorf("Size(a) = %v, %v, want 25, true", Uint128(got), ok)
+	}
+	if got := tr.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	if got := tr.Capacity(); got != (Bytes{100, 0}) {
+		t.Errorf("Capacity() = %v, want 100", Uint128(got))
+	}
+}
+
+// TestSizeTrackerMaxCapacity tests that a total that would overflow is not
+// admitted
+func TestSizeTrackerMaxCapacity(t *testing.T) {
+	tr := NewSizeTracker(Bytes(Max))
+	if evict, ok := tr.Admit("a", Bytes(Max)); evict != None || !ok {
+		t.Fatalf("Admit(a, Max) = (%v, %v), want (0, true)", Uint128(evict), ok)
+	}
+	if evict, ok := tr.Admit("b", B); evict != None || ok {
+		t.Errorf("Admit(b, 1) = (%v, %v), want (0, false)", Uint128(evict), ok)
+	}
+	if _, ok := tr.Size("b"); ok {
+		t.Errorf("Size(b) found an entry that overflowed")
+	}
+	if got := tr.Used(); got != Bytes(Max) {
+		t.Errorf("Used() = %v, want Max", Uint128(got))
+	}
+	if evict, ok := tr.Admit("a", B); evict != None || !ok {
+		t.Errorf("Admit(a, 1) = (%v, %v), want (0, true)", Uint128(evict), ok)
+	}
+	if got := tr.Used(); got != B {
+		t.Errorf("Used() after replacing a = %v, want 1", Uint128(got))
+	}
+}
+
+// TestSizeTrackerRemove tests removing entries
+func TestSizeTrackerRemove(t *testing.T) {
+	tr := NewSizeTracker(KB)
+	tr.Admit("a", Bytes{300, 0})
+	tr.Admit("b", Bytes{200, 0})
+
+	if got := tr.Remove("a"); got != (Bytes{300, 0}) {
+		t.Errorf("Remove(a) = %v, want 300", Uint128(got))
+	}
+	if got := tr.Remove("a"); got != None {
+		t.Errorf("Remove(a) again = %v, want 0", Uint128(got))
+	}
+	if got := tr.Remove("missing"); got != None {
+		t.Errorf("Remove(missing) = %v, want 0", Uint128(got))
+	}
+	if got := tr.Used(); got != (Bytes{200, 0}) {
+		t.Errorf("Used() = %v, want 200", Uint128(got))
+	}
+	if got := tr.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+}
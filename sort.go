@@ -0,0 +1,51 @@
+package bytesize
+
+import (
+	"errors"
+	"slices"
+)
+
+// SortSizeStrings sorts ss in place by the sizes they parse to with opts,
+// smallest first, like sort -h, so that "900 KB" sorts before "1 GB".
+// Equal sizes keep their order. Entries that fail to parse are moved to
+// the end, in their original order, and reported in the returned
+// ParseErrors, whose indexes refer to the positions before sorting.
+func SortSizeStrings(ss []string, opts ...ParseOption) error {
+	sizes, err := ParseAll(ss, opts...)
+	failed := make([]bool, len(ss))
+	var parseErrs ParseErrors
+	if errors.As(err, &parseErrs) {
+		for _, e := range parseErrs {
+			failed[e.Index] = true
+		}
+	} else if err != nil {
+		return err
+	}
+
+	order := make([]int, len(ss))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case failed[a] || failed[b]:
+			// Failed entries sort after all others
+			if failed[a] == failed[b] {
+				return 0
+			}
+			if failed[a] {
+				return 1
+			}
+			return -1
+		default:
+			return Uint128(sizes[a]).CmpBytes(sizes[b])
+		}
+	})
+
+	sorted := make([]string, len(ss))
+	for i, j := range order {
+		sorted[i] = ss[j]
+	}
+	copy(ss, sorted)
+	return err
+}
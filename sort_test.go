@@ -0,0 +1,76 @@
+package bytesize
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestSortSizeStrings tests sorting strings by the sizes they parse to
+func TestSortSizeStrings(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []string
+		want       []string
+		wantFailed []int
+	}{
+		{"empty", nil, nil, nil},
+		{
+			"mixed units",
+			[]string{"1 GB", "900 KB", "1 GiB", "2 B", "1.5 MB"},
+			[]string{"2 B", "900 KB", "1.5 MB", "1 GB", "1 GiB"},
+			nil,
+		},
+		{
+			"equal sizes keep order",
+			[]string{"1 KiB", "1 MB", "1024 B", "1 kibibyte"},
+			[]string{"1 KiB", "1024 B", "1 kibibyte", "1 MB"},
+			nil,
+		},
+		{
+			"failures last",
+			[]string{"bogus", "1 GB", "", "1 KB"},
+			[]string{"1 KB", "1 GB", "bogus", ""},
+			[]int{0, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := slices.Clone(tt.input)
+			err := SortSizeStrings(ss)
+			if !slices.Equal(ss, tt.want) {
+				t.Errorf("SortSizeStrings() sorted to %q, want %q", ss, tt.want)
+			}
+
+			var parseErrs ParseErrors
+			if tt.wantFailed == nil {
+				if err != nil {
+					t.Errorf("SortSizeStrings() error = %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &parseErrs) {
+				t.Fatalf("SortSizeStrings() error = %v, want ParseErrors", err)
+			}
+			var failed []int
+			for _, e := range parseErrs {
+				failed = append(failed, e.Index)
+			}
+			if !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("SortSizeStrings() failed indexes = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
+
+// TestSortSizeStringsOptions tests that the parse options apply
+func TestSortSizeStringsOptions(t *testing.T) {
+	ss := []string{"1Gi", "1Mi", "1Ki"}
+	if err := SortSizeStrings(ss, WithKubernetesSuffixParsing()); err != nil {
+		t.Fatalf("SortSizeStrings() error = %v", err)
+	}
+	if want := []string{"1Ki", "1Mi", "1Gi"}; !slices.Equal(ss, want) {
+		t.Errorf("SortSizeStrings() sorted to %q, want %q", ss, want)
+	}
+}
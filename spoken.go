@@ -0,0 +1,105 @@
+package bytesize
+
+import (
+	"slices"
+	"strings"
+)
+
+// FormatSpoken formats b in words for text-to-speech and screen readers,
+// such as "one point five gigabytes" for 1.5 GB, so that the unit is not
+// read out as letters. The value is rounded to two decimal places as by
+// String, any trailing zeros are dropped, and the unit is chosen as by
+// String with the package defaults.
+func FormatSpoken(b Bytes) string {
+	formatOptions := newFormatOptions()
+	formatOptions.formatStr = "%.2f %s"
+	formatOptions.forcedUnit = nil
+	formatOptions.longUnits = true
+	formatOptions.pluralSuffix = ""
+
+	number, unit, _ := strings.Cut(b.formatWith(formatOptions), " ")
+	if strings.Contains(number, ".") {
+		number = strings.TrimRight(strings.TrimRight(number, "0"), ".")
+	}
+	unit = strings.ToLower(unit)
+	if number != "1" {
+		unit += "s"
+	}
+	return numberWords(number) + " " + unit
+}
+
+// numberWords returns the decimal number s, digits with an optional
+// fractional part, in words, reading each digit after the point on its
+// own: "twelve point zero five" for "12.05".
+func numberWords(s string) string {
+	whole, frac, _ := strings.Cut(s, ".")
+	var n uint64
+	for _, c := range whole {
+		n = n*10 + uint64(c-'0')
+	}
+	words := []string{wholeWords(n)}
+	if frac != "" {
+		words = append(words, "point")
+		for _, c := range frac {
+			words = append(words, smallNumberWords[c-'0'])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// wholeWords returns n in words, e.g. "one thousand two hundred
+// thirty-four".
+func wholeWords(n uint64) string {
+	if n == 0 {
+		return smallNumberWords[0]
+	}
+	var groups []string
+	for i := 0; n > 0; i++ {
+		if group := n % 1000; group > 0 {
+			words := hundredWords(group)
+			if scaleWords[i] != "" {
+				words += " " + scaleWords[i]
+			}
+			groups = append(groups, words)
+		}
+		n /= 1000
+	}
+	slices.Reverse(groups)
+	return strings.Join(groups, " ")
+}
+
+// hundredWords returns n, from 1 to 999, in words.
+func hundredWords(n uint64) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, smallNumberWords[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, smallNumberWords[n])
+	case n%10 == 0:
+		words = append(words, tensWords[n/10])
+	default:
+		words = append(words, tensWords[n/10]+"-"+smallNumberWords[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// smallNumberWords, tensWords and scaleWords are the English words
+// numbers are written with: the numbers below twenty, the multiples of
+// ten, and the powers of a thousand.
+var (
+	smallNumberWords = [...]string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	tensWords = [...]string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+	}
+	scaleWords = [...]string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+	}
+)
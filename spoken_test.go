@@ -0,0 +1,55 @@
+package bytesize
+
+import "testing"
+
+// TestFormatSpoken tests formatting sizes in words
+func TestFormatSpoken(t *testing.T) {
+	tests := []struct {
+		b    Bytes
+		want string
+	}{
+		{None, "zero bytes"},
+		{B, "one byte"},
+		{Bytes{512, 0}, "five hundred twelve bytes"},
+		{Bytes{1_500_000_000, 0}, "one point five gigabytes"},
+		{GB, "one gigabyte"},
+		{Bytes{1_004_000_000, 0}, "one gigabyte"},
+		{Bytes{1_050_000, 0}, "one point zero five megabytes"},
+		{Bytes{21_340, 0}, "twenty-one point three four kilobytes"},
+		{times(TB, 990), "nine hundred ninety terabytes"},
+		{Bytes(Max), "three hundred forty million two hundred eighty-two thousand three hundred sixty-six point nine two quettabytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatSpoken(tt.b); got != tt.want {
+				t.Errorf("FormatSpoken(%v) = %q, want %q", tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWholeWords tests writing whole numbers in words
+func TestWholeWords(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "zero"},
+		{7, "seven"},
+		{19, "nineteen"},
+		{40, "forty"},
+		{101, "one hundred one"},
+		{1000, "one thousand"},
+		{1_000_001, "one million one"},
+		{18_446_744_073_709_551_615, "eighteen quintillion four hundred forty-six quadrillion seven hundred forty-four trillion seventy-three billion seven hundred nine million five hundred fifty-one thousand six hundred fifteen"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := wholeWords(tt.n); got != tt.want {
+				t.Errorf("wholeWords(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package bytesize
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// thresholdOps are the comparisons a Threshold may use, longest first so
+// that ">=" is not read as ">" followed by "=".
+var thresholdOps = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+// Threshold is a condition on a size, such as "> 1.5 GiB" or
+// ">= 80% of 2 TiB", for alerting configurations whose conditions the
+// package evaluates directly. The zero Threshold is never exceeded; use
+// ParseThreshold to make one.
+type Threshold struct {
+	op      string
+	percent *big.Rat // the percentage of size, or nil for size itself
+	size    Bytes
+	text    string // the condition as written, trimmed
+}
+
+// ParseThreshold parses a threshold written as a comparison, one of ">",
+// ">=", "<", "<=", "==" (or "=") and "!=", followed by a size, such as
+// "> 1.5 GiB", or by a percentage of a size, such as ">= 80% of 2 TiB". The
+// size is parsed like Parse with opts. The percentage is written in decimal
+// digits with at most one decimal point, such as "80" or "0.5", and may
+// exceed 100.
+func ParseThreshold(s string, opts ...ParseOption) (Threshold, error) {
+	text := strings.TrimSpace(s)
+	var t Threshold
+	for _, op := range thresholdOps {
+		if rest, ok := strings.CutPrefix(text, op); ok {
+			t.op, text = op, rest
+			break
+		}
+	}
+	if t.op == "" {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: missing comparison", s)
+	}
+
+	sizeStr := text
+	if pctStr, rest, ok := strings.Cut(text, "%"); ok {
+		ofSize, ok := strings.CutPrefix(strings.TrimSpace(rest), "of")
+		if !ok {
+			return Threshold{}, fmt.Errorf("invalid threshold %q: missing \"of\" after percentage", s)
+		}
+		pctStr = strings.TrimSpace(pctStr)
+		if !isPercentage(pctStr) {
+			return Threshold{}, fmt.Errorf("invalid threshold %q: invalid percentage %q", s, pctStr)
+		}
+		pct, _ := new(big.Rat).SetString(pctStr)
+		t.percent, sizeStr = pct, ofSize
+	}
+
+	size, err := Parse(sizeStr, opts...)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: %w", s, err)
+	}
+	t.size = size
+	t.text = strings.TrimSpace(s)
+	return t, nil
+}
+
+// isPercentage reports whether s matches [0-9]*\.?[0-9]* with at least one
+// digit.
+func isPercentage(s string) bool {
+	digits, point := false, false
+	for _, r := range s {
+		switch {
+		case '0' <= r && r <= '9':
+			digits = true
+		case r == '.' && !point:
+			point = true
+		default:
+			return false
+		}
+	}
+	return digits
+}
+
+// Exceeded reports whether current meets the threshold's condition, such
+// as whether current is at least 80% of 2 TiB for ">= 80% of 2 TiB". The
+// comparison is exact, even when the percentage of the size is not a whole
+// number of bytes.
+func (t Threshold) Exceeded(current Bytes) bool {
+	var c int
+	if t.percent == nil {
+		c = Uint128(current).CmpBytes(t.size)
+	} else {
+		// Compare 100 × current with percent × size
+		lhs := new(big.Rat).SetInt(Uint128(current).Big())
+		lhs.Mul(lhs, big.NewRat(100, 1))
+		rhs := new(big.Rat).SetInt(Uint128(t.size).Big())
+		rhs.Mul(rhs, t.percent)
+		c = lhs.Cmp(rhs)
+	}
+
+	switch t.op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case "==", "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	default:
+		return false
+	}
+}
+
+// Limit returns the size at which the threshold's condition changes, the
+// percentage of the size rounded down to a whole byte for a percentage
+// threshold. It saturates at Max.
+func (t Threshold) Limit() Bytes {
+	if t.percent == nil {
+		return t.size
+	}
+	n := new(big.Int).Mul(Uint128(t.size).Big(), t.percent.Num())
+	n.Quo(n, new(big.Int).Mul(t.percent.Denom(), big.NewInt(100)))
+	u, err := FromBigErr(n)
+	if err != nil {
+		return Bytes(Max)
+	}
+	return Bytes(u)
+}
+
+// String returns the threshold as it was written to ParseThreshold.
+func (t Threshold) String() string {
+	return t.text
+}
@@ -0,0 +1,104 @@
+package bytesize
+
+import "testing"
+
+// TestThresholdExceeded tests evaluating parsed thresholds
+func TestThresholdExceeded(t *testing.T) {
+	tests := []struct {
+		threshold string
+		current   Bytes
+		want      bool
+	}{
+		{"> 1.5 GiB", Bytes{1 << 30 * 3 / 2, 0}, false},
+		{"> 1.5 GiB", Bytes{1<<30*3/2 + 1, 0}, true},
+		{">= 1.5 GiB", Bytes{1 << 30 * 3 / 2, 0}, true},
+		{"< 10 GB", times(GB, 9), true},
+		{"<= 10 GB", times(GB, 11), false},
+		{"== 1 KiB", Bytes{1024, 0}, true},
+		{"= 1 KiB", Bytes{1000, 0}, false},
+		{"!= 1 KiB", Bytes{1000, 0}, true},
+		{">= 80% of 2 TiB", Bytes{1759218604441, 0}, false},
+		{">= 80% of 2 TiB", Bytes{1759218604442, 0}, true},
+		{">= 80% of 10 B", Bytes{8, 0}, true},
+		{"> 33.4%of 3 B", B, false},
+		{"> 33.3%of 3 B", B, true},
+		{"> 33.3% of 3 B", Bytes{2, 0}, true},
+		{">150% of 1 GB", times(MB, 1500), false},
+		{">150% of 1 GB", times(MB, 1501), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.threshold, func(t *testing.T) {
+			th, err := ParseThreshold(tt.threshold)
+			if err != nil {
+				t.Fatalf("ParseThreshold(%q) error = %v", tt.threshold, err)
+			}
+			if got := th.Exceeded(tt.current); got != tt.want {
+				t.Errorf("Exceeded(%v) = %v, want %v", tt.current, got, tt.want)
+			}
+			if got := th.String(); got != tt.threshold {
+				t.Errorf("String() = %q, want %q", got, tt.threshold)
+			}
+		})
+	}
+}
+
+// TestThresholdLimit tests the size at which a threshold changes
+func TestThresholdLimit(t *testing.T) {
+	tests := []struct {
+		threshold string
+		want      Bytes
+	}{
+		{"> 1.5 GiB", Bytes{1 << 30 * 3 / 2, 0}},
+		{">= 80% of 2 TiB", Bytes{1759218604441, 0}},
+		{">= 0.5% of 1 KB", Bytes{5, 0}},
+		{">= .5% of 1 KB", Bytes{5, 0}},
+		{">= 50.% of 1 KB", Bytes{500, 0}},
+		{">= 200% of 1 KB", Bytes{2000, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.threshold, func(t *testing.T) {
+			th, err := ParseThreshold(tt.threshold)
+			if err != nil {
+				t.Fatalf("ParseThreshold(%q) error = %v", tt.threshold, err)
+			}
+			if got := th.Limit(); got != tt.want {
+				t.Errorf("Limit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseThresholdErrors tests rejecting malformed thresholds
+func TestParseThresholdErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"1 GB",
+		"=> 1 GB",
+		"> ",
+		"> 1 XB",
+		">= 80% 2 TiB",
+		">= -5% of 2 TiB",
+		">= x% of 2 TiB",
+		">= 1/3% of 2 TiB",
+		">= 1e2% of 2 TiB",
+		">= 1.2.3% of 2 TiB",
+		">= .% of 2 TiB",
+		">= +5% of 2 TiB",
+		">= 80% of",
+	} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseThreshold(s); err == nil {
+				t.Errorf("ParseThreshold(%q) returned no error", s)
+			}
+		})
+	}
+}
+
+// TestThresholdZero tests that the zero Threshold is never exceeded
+func TestThresholdZero(t *testing.T) {
+	if (Threshold{}).Exceeded(Bytes(Max)) {
+		t.Error("zero Threshold was exceeded")
+	}
+}
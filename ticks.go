@@ -0,0 +1,89 @@
+package bytesize
+
+// NiceTicks returns about count evenly spaced tick values for a chart axis
+// spanning min to max. The spacing is 1, 2 or 5 times a power of ten bytes,
+// so every tick formats to a short label in SI units, and the first and
+// last ticks are the multiples of the spacing at or just outside min and
+// max. It returns nil if count is less than 2, and just min if min equals
+// max. min and max may be given in either order. Use NiceTicksIn for an
+// axis labelled in IEC or JEDEC units.
+func NiceTicks(min, max Bytes, count int) []Bytes {
+	return NiceTicksIn(SI, min, max, count)
+}
+
+// NiceTicksIn is like NiceTicks, but spaces the ticks to suit the units of
+// system. For IEC and JEDEC the spacing is a power of two bytes, so the
+// ticks of a 1 GiB axis label as 0, 256 MiB, 512 MiB, 768 MiB, 1 GiB rather
+// than 0, 238.42 MiB, 476.84 MiB, 715.26 MiB, 953.67 MiB.
+func NiceTicksIn(system UnitSystem, min, max Bytes, count int) []Bytes {
+	if count < 2 {
+		return nil
+	}
+	lo, hi := Uint128(min), Uint128(max)
+	if lo.Cmp(hi) > 0 {
+		lo, hi = hi, lo
+	}
+	if lo.Equals(hi) {
+		return []Bytes{Bytes(lo)}
+	}
+
+	// The smallest nice step that spans the range in count-1 steps
+	raw, rem := hi.Sub(lo).QuoRem64(uint64(count - 1))
+	if rem != 0 {
+		raw = raw.Add64(1)
+	}
+	step, ok := niceStep(raw, system)
+	if !ok {
+		return []Bytes{Bytes(lo), Bytes(hi)}
+	}
+
+	first := lo.Sub(lo.Mod(step))
+	last, err := hi.AddErr(step.Sub(hi.Mod(step)).Mod(step))
+	if err != nil {
+		// Rounding hi up to a multiple of step would overflow
+		last = hi.Sub(hi.Mod(step))
+	}
+
+	var ticks []Bytes
+	for tick := first; tick.Cmp(last) <= 0; {
+		ticks = append(ticks, Bytes(tick))
+		next, err := tick.AddErr(step)
+		if err != nil {
+			break
+		}
+		tick = next
+	}
+	return ticks
+}
+
+// niceMultiples are the multiples of a power of niceBases a nice step may
+// be, for decimal and binary systems.
+var (
+	niceMultiples = [2][]uint64{{1, 2, 5}, {1, 2, 4, 8, 16, 32, 64, 128, 256, 512}}
+	niceBases     = [2]uint64{10, 1024}
+)
+
+// niceStep returns the smallest nice step for system that is at least raw.
+// It reports false if that does not fit in 128 bits.
+func niceStep(raw Uint128, system UnitSystem) (Uint128, bool) {
+	binary := 0
+	if system == IEC || system == JEDEC {
+		binary = 1
+	}
+	for pow := From64(1); ; {
+		for _, m := range niceMultiples[binary] {
+			step, err := pow.Mul64Err(m)
+			if err != nil {
+				return Uint128{}, false
+			}
+			if step.Cmp(raw) >= 0 {
+				return step, true
+			}
+		}
+		next, err := pow.Mul64Err(niceBases[binary])
+		if err != nil {
+			return Uint128{}, false
+		}
+		pow = next
+	}
+}
@@ -0,0 +1,92 @@
+package bytesize
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestNiceTicks tests generating human-friendly axis ticks
+func TestNiceTicks(t *testing.T) {
+	b := func(vs ...uint64) []Bytes {
+		out := make([]Bytes, len(vs))
+		for i, v := range vs {
+			out[i] = Bytes{v, 0}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		min, max Bytes
+		count    int
+		want     []Bytes
+	}{
+		{"round range", None, Bytes{1000, 0}, 6, b(0, 200, 400, 600, 800, 1000)},
+		{"uneven range", Bytes{130, 0}, Bytes{870, 0}, 5, b(0, 200, 400, 600, 800, 1000)},
+		{"step of five", None, GB, 3, b(0, 500_000_000, 1_000_000_000)},
+		{"step of one", Bytes{3, 0}, Bytes{7, 0}, 5, b(3, 4, 5, 6, 7)},
+		{"swapped", Bytes{1000, 0}, None, 6, b(0, 200, 400, 600, 800, 1000)},
+		{"equal", KB, KB, 5, b(1000)},
+		{"too few", None, KB, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NiceTicks(tt.min, tt.max, tt.count); !slices.Equal(got, tt.want) {
+				t.Errorf("NiceTicks(%v, %v, %d) = %v, want %v", Uint128(tt.min), Uint128(tt.max), tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNiceTicksIn tests spacing ticks to suit a unit system
+func TestNiceTicksIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		system   UnitSystem
+		min, max Bytes
+		count    int
+		want     []Bytes
+	}{
+		{"SI", SI, None, GB, 5, []Bytes{None, times(MB, 500), GB}},
+		{"IEC", IEC, None, GiB, 5, []Bytes{None, times(MiB, 256), times(MiB, 512), times(MiB, 768), GiB}},
+		{"JEDEC", JEDEC, None, GiB, 5, []Bytes{None, times(MiB, 256), times(MiB, 512), times(MiB, 768), GiB}},
+		{"IEC bytes", IEC, None, Bytes{900, 0}, 3, []Bytes{None, Bytes{512, 0}, KiB}},
+		{"IEC across units", IEC, None, times(KiB, 3), 3, []Bytes{None, times(KiB, 2), times(KiB, 4)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NiceTicksIn(tt.system, tt.min, tt.max, tt.count); !slices.Equal(got, tt.want) {
+				t.Errorf("NiceTicksIn(%v, %v, %v, %d) = %v, want %v", tt.system, Uint128(tt.min), Uint128(tt.max), tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNiceTicksFullRange tests that ticks near the top of the range do not
+// overflow
+func TestNiceTicksFullRange(t *testing.T) {
+	ticks := NiceTicks(None, Bytes(Max), 5)
+	if len(ticks) < 2 {
+		t.Fatalf("NiceTicks(0, Max, 5) = %v", ticks)
+	}
+	if !slices.IsSortedFunc(ticks, func(a, b Bytes) int { return Uint128(a).CmpBytes(b) }) {
+		t.Errorf("NiceTicks(0, Max, 5) = %v is not ascending", ticks)
+	}
+	if ticks[0] != None {
+		t.Errorf("NiceTicks(0, Max, 5)[0] = %v, want 0", Uint128(ticks[0]))
+	}
+}
+
+// TestNiceTicksInFullRange tests that binary ticks near the top of the
+// range do not overflow
+func TestNiceTicksInFullRange(t *testing.T) {
+	ticks := NiceTicksIn(IEC, None, Bytes(Max), 5)
+	if len(ticks) < 2 {
+		t.Fatalf("NiceTicksIn(IEC, 0, Max, 5) = %v", ticks)
+	}
+	if !slices.IsSortedFunc(ticks, func(a, b Bytes) int { return Uint128(a).CmpBytes(b) }) {
+		t.Errorf("NiceTicksIn(IEC, 0, Max, 5) = %v is not ascending", ticks)
+	}
+}
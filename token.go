@@ -0,0 +1,57 @@
+package bytesize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenDigits are the digits of a Token, in order, all of which are safe
+// in URLs and file names.
+const tokenDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Token returns b as a compact base 62 number, at most 22 characters from
+// [0-9A-Za-z], e.g. "15ftgG" for 1 GB, for embedding exact sizes in URLs
+// and cache keys without spaces or unit ambiguity. Each size has exactly
+// one token, which ParseToken turns back into the size.
+func (b Bytes) Token() string {
+	u := Uint128(b)
+	if u.IsZero() {
+		return "0"
+	}
+	var buf [22]byte // 62^22 > 2^128
+	i := len(buf)
+	for !u.IsZero() {
+		var digit uint64
+		u, digit = u.QuoRem64(62)
+		i--
+		buf[i] = tokenDigits[digit]
+	}
+	return string(buf[i:])
+}
+
+// ParseToken parses a token returned by Token. It returns an error if s is
+// not a token of a size: if it is empty, has a leading zero, contains a
+// character other than [0-9A-Za-z], or is 2^128 or more.
+func ParseToken(s string) (Bytes, error) {
+	if s == "" {
+		return Bytes{}, fmt.Errorf("empty token")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return Bytes{}, fmt.Errorf("invalid token %q: leading zero", s)
+	}
+	var u Uint128
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(tokenDigits, s[i])
+		if digit < 0 {
+			return Bytes{}, fmt.Errorf("invalid token %q: unexpected %q", s, s[i])
+		}
+		var err error
+		if u, err = u.Mul64Err(62); err == nil {
+			u, err = u.Add64Err(uint64(digit))
+		}
+		if err != nil {
+			return Bytes{}, fmt.Errorf("invalid token %q: value overflows Uint128", s)
+		}
+	}
+	return Bytes(u), nil
+}
@@ -0,0 +1,71 @@
+package bytesize
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// TestToken tests encoding sizes as tokens
+func TestToken(t *testing.T) {
+	tests := []struct {
+		b    Bytes
+		want string
+	}{
+		{None, "0"},
+		{B, "1"},
+		{Bytes{61, 0}, "z"},
+		{Bytes{62, 0}, "10"},
+		{GB, "15ftgG"},
+		{Bytes(Max), "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.b.Token(); got != tt.want {
+				t.Errorf("Token() = %q, want %q", got, tt.want)
+			}
+			got, err := ParseToken(tt.want)
+			if err != nil || got != tt.b {
+				t.Errorf("ParseToken(%q) = %v, %v, want %v", tt.want, Uint128(got), err, Uint128(tt.b))
+			}
+		})
+	}
+}
+
+// TestTokenRoundTrip tests that random sizes survive a round trip
+func TestTokenRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 1000 {
+		b := Bytes{rng.Uint64(), rng.Uint64() >> rng.IntN(64)}
+		token := b.Token()
+		got, err := ParseToken(token)
+		if err != nil || got != b {
+			t.Fatalf("ParseToken(%q) = %v, %v, want %v", token, Uint128(got), err, Uint128(b))
+		}
+	}
+}
+
+// TestParseTokenErrors tests that invalid tokens are rejected
+func TestParseTokenErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"", "empty token"},
+		{"01", "leading zero"},
+		{"1-2", `unexpected '-'`},
+		{"1 GB", `unexpected ' '`},
+		{"7n42DGM5Tflk9n8mt7Fhc8", "overflows"},
+		{"zzzzzzzzzzzzzzzzzzzzzzz", "overflows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseToken(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseToken(%q) error = %v, want containing %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package bytesize
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// asciiSpace reports which ASCII bytes unicode.IsSpace reports as
+// whitespace.
+var asciiSpace = [utf8.RuneSelf]bool{'\t': true, '\n': true, '\v': true, '\f': true, '\r': true, ' ': true}
+
+// tokenizeBytes separates the numeric part and the unit part of s exactly
+// as getNumAndUnitRunes does, but in a single pass over the bytes of s,
+// returning substrings of s rather than rune slices. Only non-ASCII input
+// is decoded into runes, and only a unit with whitespace between its words
+// or with invalid UTF-8 is copied.
+func tokenizeBytes(s string, unitSeparators bool) (num, unit string, err error) {
+	foundDecimalPoint := false
+	numStart, numEnd := 0, 0
+	unitStart, unitEnd := 0, 0
+	unitSpaces := false  // whether whitespace separates words of the unit
+	unitInvalid := false // whether the unit has invalid UTF-8
+	state := stateLeading
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		r, size := rune(c), 1
+		var isSpace bool
+		if c < utf8.RuneSelf {
+			isSpace = asciiSpace[c]
+		} else {
+			r, size = utf8.DecodeRuneInString(s[i:])
+			isSpace = unicode.IsSpace(r)
+		}
+
+		switch {
+		case isSpace:
+			// 1. Whitespace ends whichever token we are in
+			switch state {
+			case stateSign:
+				return "", "", &SyntaxError{s, i, "invalid number: whitespace after sign"}
+			case stateNumber:
+				state = stateGap
+			case stateUnit:
+				state = stateTrailing
+			}
+		case c == '-' && unitSeparators && (state == stateUnit || state == stateTrailing):
+			// 2. A hyphen may separate the words of the unit
+			unitSpaces = unitSpaces || state == stateTrailing
+			state = stateUnit
+			unitEnd = i + 1
+		case c == '-' || c == '+':
+			// 3. A sign may only start the number
+			if state != stateLeading {
+				return "", "", &SyntaxError{s, i, fmt.Sprintf("unexpected %q", r)}
+			}
+			state = stateSign
+			numStart, numEnd = i, i+1
+		case c >= '0' && c <= '9' || c == '.':
+			// 4. Digits and the decimal point make up the number
+			switch state {
+			case stateLeading:
+				numStart = i
+			case stateSign, stateNumber:
+			default:
+				return "", "", &SyntaxError{s, i, fmt.Sprintf("unexpected %q", r)}
+			}
+			if c == '.' {
+				if foundDecimalPoint {
+					return "", "", &SyntaxError{s, i, "invalid number: multiple decimal points"}
+				}
+				foundDecimalPoint = true
+			}
+			state = stateNumber
+			numEnd = i + 1
+		default:
+			// 5. The rest is the unit
+			switch state {
+			case stateSign:
+				return "", "", &SyntaxError{s, i, "invalid number: sign without digits"}
+			case stateTrailing:
+				if !unitSeparators {
+					return "", "", &SyntaxError{s, i, fmt.Sprintf("unexpected %q after unit", r)}
+				}
+				unitSpaces = true
+			case stateUnit:
+			default:
+				unitStart = i
+			}
+			state = stateUnit
+			unitEnd = i + size
+			unitInvalid = unitInvalid || r == utf8.RuneError && size == 1
+		}
+		i += size
+	}
+
+	if state == stateSign {
+		return "", "", &SyntaxError{s, len(s), "invalid number: sign without digits"}
+	}
+
+	unit = s[unitStart:unitEnd]
+	if unitSpaces || unitInvalid {
+		// Drop the whitespace and replace each invalid byte with U+FFFD,
+		// as ranging over the runes of s does
+		unit = strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, unit)
+	}
+	return s[numStart:numEnd], unit, nil
+}
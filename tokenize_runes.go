@@ -0,0 +1,87 @@
+package bytesize
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// getNumAndUnitRunes separates the numeric part and the unit part of the
+// input string. The input must be an optionally signed number followed by
+// an optional unit, each of which may be surrounded by whitespace;
+// anything else is reported as a *SyntaxError. If unitSeparators is true,
+// the words of the unit may also be separated by whitespace and hyphens,
+// as in "gibi byte" or "kibi-bytes"; the whitespace is dropped and the
+// hyphens are kept in the unit.
+//
+// It is the tokenizer Parse used before tokenizeBytes, kept as the
+// reference tokenizeBytes is fuzzed against and used by Parse in builds
+// with the bytesize_runetokenizer tag.
+func getNumAndUnitRunes(s string, unitSeparators bool) ([]rune, []rune, error) {
+	foundDecimalPoint := false
+	var numRunes, unitRunes []rune
+	state := stateLeading
+
+	for i, r := range s {
+		isSpace := unicode.IsSpace(r)
+		isDigit := (r >= '0' && r <= '9') || r == '.'
+		isSign := r == '-' || r == '+'
+
+		switch {
+		case isSpace:
+			// 1. Whitespace ends whichever token we are in
+			switch state {
+			case stateSign:
+				return nil, nil, &SyntaxError{s, i, "invalid number: whitespace after sign"}
+			case stateNumber:
+				state = stateGap
+			case stateUnit:
+				state = stateTrailing
+			}
+			continue
+		case r == '-' && unitSeparators && (state == stateUnit || state == stateTrailing):
+			// 2. A hyphen may separate the words of the unit
+			state = stateUnit
+			unitRunes = append(unitRunes, r)
+			continue
+		case isSign:
+			// 3. A sign may only start the number
+			if state != stateLeading {
+				return nil, nil, &SyntaxError{s, i, fmt.Sprintf("unexpected %q", r)}
+			}
+			state = stateSign
+		case isDigit:
+			// 4. Digits and the decimal point make up the number
+			if state != stateLeading && state != stateSign && state != stateNumber {
+				return nil, nil, &SyntaxError{s, i, fmt.Sprintf("unexpected %q", r)}
+			}
+			if r == '.' {
+				if foundDecimalPoint {
+					return nil, nil, &SyntaxError{s, i, "invalid number: multiple decimal points"}
+				}
+				foundDecimalPoint = true
+			}
+			state = stateNumber
+		default:
+			// 5. The rest is the unit
+			switch state {
+			case stateSign:
+				return nil, nil, &SyntaxError{s, i, "invalid number: sign without digits"}
+			case stateTrailing:
+				if unitSeparators {
+					break
+				}
+				return nil, nil, &SyntaxError{s, i, fmt.Sprintf("unexpected %q after unit", r)}
+			}
+			state = stateUnit
+			unitRunes = append(unitRunes, r)
+			continue
+		}
+		numRunes = append(numRunes, r)
+	}
+
+	if state == stateSign {
+		return nil, nil, &SyntaxError{s, len(s), "invalid number: sign without digits"}
+	}
+
+	return numRunes, unitRunes, nil
+}
@@ -0,0 +1,10 @@
+//go:build !bytesize_runetokenizer
+
+package bytesize
+
+// tokenize separates the numeric part and the unit part of s. Builds with
+// the bytesize_runetokenizer tag use getNumAndUnitRunes instead of
+// tokenizeBytes, as a fallback while tokenizeBytes is new.
+func tokenize(s string, unitSeparators bool) (num, unit string, err error) {
+	return tokenizeBytes(s, unitSeparators)
+}
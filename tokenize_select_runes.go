@@ -0,0 +1,13 @@
+//go:build bytesize_runetokenizer
+
+package bytesize
+
+// tokenize separates the numeric part and the unit part of s with
+// getNumAndUnitRunes, the tokenizer Parse used before tokenizeBytes.
+func tokenize(s string, unitSeparators bool) (num, unit string, err error) {
+	numRunes, unitRunes, err := getNumAndUnitRunes(s, unitSeparators)
+	if err != nil {
+		return "", "", err
+	}
+	return string(numRunes), string(unitRunes), nil
+}
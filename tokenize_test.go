@@ -0,0 +1,129 @@
+package bytesize
+
+import (
+	"fmt"
+	"testing"
+)
+
+// tokenizeInputs are inputs on which tokenizeBytes must agree with
+// getNumAndUnitRunes, covering each state and error of the tokenizer.
+var tokenizeInputs = []string{
+	"",
+	"   ",
+	"10 MB",
+	"10MB",
+	"  10   MB  ",
+	"\t50\tGB\n",
+	"1.5 GiB",
+	".5 KB",
+	"5. KB",
+	"+5 KB",
+	"-5 KB",
+	"- 5 KB",
+	"+",
+	"-",
+	"+MB",
+	"5 -MB",
+	"1.2.3 KB",
+	"1 2 MB",
+	"MB",
+	"1 MB 2",
+	"1 MB x",
+	"1 gibi byte",
+	"1 gibi  bytes ",
+	"1 kibi-bytes",
+	"1 kibi -bytes",
+	"1 kibi- bytes",
+	"1 MB-",
+	"1-MB",
+	"1 MB",
+	"1 MB　",
+	"\u00851 MB",
+	"10 µB",
+	"10 Mó",
+	"1 \xff",
+	"\xff",
+	"1 M\xffB x",
+	"1 kilo - bytes ",
+}
+
+// TestTokenizeBytes tests that tokenizeBytes agrees with getNumAndUnitRunes
+func TestTokenizeBytes(t *testing.T) {
+	for _, input := range tokenizeInputs {
+		for _, unitSeparators := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%q/%v", input, unitSeparators), func(t *testing.T) {
+				checkTokenizeBytes(t, input, unitSeparators)
+			})
+		}
+	}
+}
+
+// TestTokenizeBytesResults tests the tokens of some inputs
+func TestTokenizeBytesResults(t *testing.T) {
+	tests := []struct {
+		input          string
+		unitSeparators bool
+		num, unit      string
+	}{
+		{"  10   MB  ", false, "10", "MB"},
+		{"-1.5KiB", false, "-1.5", "KiB"},
+		{"MB", false, "", "MB"},
+		{"42", false, "42", ""},
+		{"1 gibi  bytes ", true, "1", "gibibytes"},
+		{"1 kibi - bytes", true, "1", "kibi-bytes"},
+		{"1 MB", false, "1", "MB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			num, unit, err := tokenizeBytes(tt.input, tt.unitSeparators)
+			if err != nil {
+				t.Fatalf("tokenizeBytes(%q) error = %v", tt.input, err)
+			}
+			if num != tt.num || unit != tt.unit {
+				t.Errorf("tokenizeBytes(%q) = %q, %q, want %q, %q", tt.input, num, unit, tt.num, tt.unit)
+			}
+		})
+	}
+}
+
+// FuzzTokenizeBytes differentially fuzzes tokenizeBytes against
+// getNumAndUnitRunes
+func FuzzTokenizeBytes(f *testing.F) {
+	for _, input := range tokenizeInputs {
+		f.Add(input, false)
+		f.Add(input, true)
+	}
+
+	f.Fuzz(func(t *testing.T, input string, unitSeparators bool) {
+		checkTokenizeBytes(t, input, unitSeparators)
+	})
+}
+
+// checkTokenizeBytes checks that tokenizeBytes returns the same tokens or
+// error as getNumAndUnitRunes for input.
+func checkTokenizeBytes(t *testing.T, input string, unitSeparators bool) {
+	t.Helper()
+	num, unit, err := tokenizeBytes(input, unitSeparators)
+	wantNum, wantUnit, wantErr := getNumAndUnitRunes(input, unitSeparators)
+	if (err != nil) != (wantErr != nil) || err != nil && err.Error() != wantErr.Error() {
+		t.Fatalf("tokenizeBytes(%q, %v) error = %v, want %v", input, unitSeparators, err, wantErr)
+	}
+	if num != string(wantNum) || unit != string(wantUnit) {
+		t.Errorf("tokenizeBytes(%q, %v) = %q, %q, want %q, %q", input, unitSeparators, num, unit, string(wantNum), string(wantUnit))
+	}
+}
+
+// BenchmarkTokenizeBytes benchmarks the single-pass tokenizer
+func BenchmarkTokenizeBytes(b *testing.B) {
+	for b.Loop() {
+		tokenizeBytes("  1.5 GiB ", false)
+	}
+}
+
+// BenchmarkGetNumAndUnitRunes benchmarks the rune slice tokenizer
+func BenchmarkGetNumAndUnitRunes(b *testing.B) {
+	for b.Loop() {
+		getNumAndUnitRunes("  1.5 GiB ", false)
+	}
+}
@@ -428,7 +428,8 @@ func (u Uint128) Div64(v uint64) Uint128 {
 	return q
 }
 
-// QuoRem returns q = u/v and r = u%v.
+// QuoRem returns q = u/v and r = u%v, using full 128-by-128-bit division
+// without math/big. Div and Mod return its two halves.
 func (u Uint128) QuoRem(v Uint128) (q, r Uint128) {
 	if v.Hi == 0 {
 		var r64 uint64
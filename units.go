@@ -0,0 +1,207 @@
+package bytesize
+
+import (
+	"fmt"
+	"maps"
+)
+
+// UnitSystem identifies a family of byte size units.
+type UnitSystem int
+
+const (
+	// SI is the decimal system of units that are powers of 1000, such as
+	// KB and MB.
+	SI UnitSystem = iota
+	// IEC is the binary system of units that are powers of 1024, such as
+	// KiB and MiB.
+	IEC
+	// JEDEC is the memory industry convention, from JEDEC Standard 100B.01,
+	// of giving the powers of 1024 up to GB the names of the SI units.
+	JEDEC
+)
+
+// String returns the name of the unit system.
+func (s UnitSystem) String() string {
+	switch s {
+	case SI:
+		return "SI"
+	case IEC:
+		return "IEC"
+	case JEDEC:
+		return "JEDEC"
+	default:
+		return fmt.Sprintf("UnitSystem(%d)", int(s))
+	}
+}
+
+// Unit describes a byte size unit.
+type Unit struct {
+	// Factor is the size of the unit in bytes.
+	Factor Bytes
+	// Short is the short name used when formatting, e.g. "KB".
+	Short string
+	// Long is the long name used when formatting, e.g. "Kilobyte".
+	Long string
+	// Symbol is the symbol the unit's standard specifies, e.g. "kB".
+	Symbol string
+	// System is the unit system the unit belongs to.
+	System UnitSystem
+}
+
+// byteUnit is the byte itself, which every unit system starts with.
+var byteUnit = Unit{B, "B", "Byte", "B", SI}
+
+// siUnits, iecUnits and jedecUnits list the units of each system above the
+// byte in ascending order. They must not be modified; ListUnits returns
+// copies. SI writes the kilo prefix with a lowercase k, and JEDEC Standard
+// 100B.01 stops at gigabyte.
+var (
+	siUnits = []Unit{
+		{KB, "KB", "Kilobyte", "kB", SI},
+		{MB, "MB", "Megabyte", "MB", SI},
+		{GB, "GB", "Gigabyte", "GB", SI},
+		{TB, "TB", "Terabyte", "TB", SI},
+		{PB, "PB", "Petabyte", "PB", SI},
+		{EB, "EB", "Exabyte", "EB", SI},
+		{ZB, "ZB", "Zettabyte", "ZB", SI},
+		{YB, "YB", "Yottabyte", "YB", SI},
+		{RB, "RB", "Ronnabyte", "RB", SI},
+		{QB, "QB", "Quettabyte", "QB", SI},
+	}
+	iecUnits = []Unit{
+		{KiB, "KiB", "Kibibyte", "KiB", IEC},
+		{MiB, "MiB", "Mebibyte", "MiB", IEC},
+		{GiB, "GiB", "Gibibyte", "GiB", IEC},
+		{TiB, "TiB", "Tebibyte", "TiB", IEC},
+		{PiB, "PiB", "Pebibyte", "PiB", IEC},
+		{EiB, "EiB", "Exbibyte", "EiB", IEC},
+		{ZiB, "ZiB", "Zebibyte", "ZiB", IEC},
+		{YiB, "YiB", "Yobibyte", "YiB", IEC},
+		{RiB, "RiB", "Ronnibyte", "RiB", IEC},
+		{QiB, "QiB", "Quettibyte", "QiB", IEC},
+	}
+	jedecUnits = []Unit{
+		{KiB, "KB", "Kilobyte", "KB", JEDEC},
+		{MiB, "MB", "Megabyte", "MB", JEDEC},
+		{GiB, "GB", "Gigabyte", "GB", JEDEC},
+	}
+)
+
+// The unit name tables used for formatting, keyed by factor. They must not
+// be modified; UnitNames returns copies.
+var (
+	longDecimal  = unitNameTable(siUnits, true)
+	shortDecimal = unitNameTable(siUnits, false)
+	longBinary   = unitNameTable(iecUnits, true)
+	shortBinary  = unitNameTable(iecUnits, false)
+)
+
+// unitNameTable returns the long or short names of units keyed by factor.
+func unitNameTable(units []Unit, long bool) map[Bytes]string {
+	names := make(map[Bytes]string, len(units))
+	for _, u := range units {
+		if long {
+			names[u.Factor] = u.Long
+		} else {
+			names[u.Factor] = u.Short
+		}
+	}
+	return names
+}
+
+// unitsOf returns the units of system above the byte, or nil for an unknown
+// system.
+func unitsOf(system UnitSystem) []Unit {
+	switch system {
+	case SI:
+		return siUnits
+	case IEC:
+		return iecUnits
+	case JEDEC:
+		return jedecUnits
+	default:
+		return nil
+	}
+}
+
+// ListUnits returns the units of system in ascending order, starting with
+// the byte itself. It returns nil for an unknown system.
+func ListUnits(system UnitSystem) []Unit {
+	units := unitsOf(system)
+	if units == nil {
+		return nil
+	}
+	b := byteUnit
+	b.System = system
+	return append([]Unit{b}, units...)
+}
+
+// UnitOf returns the SI or IEC Unit whose factor is b, converting one of
+// the unit variables such as KB or GiB into a Unit. B converts to the SI
+// byte. It reports false if b is not the factor of any such unit.
+func UnitOf(b Bytes) (Unit, bool) {
+	if b == B {
+		return byteUnit, true
+	}
+	for _, units := range [][]Unit{siUnits, iecUnits} {
+		for _, u := range units {
+			if u.Factor == b {
+				return u, true
+			}
+		}
+	}
+	return Unit{}, false
+}
+
+// String returns the short name of the unit.
+func (u Unit) String() string {
+	return u.Short
+}
+
+// UnitNames returns the long or short names of the units of system, keyed
+// by unit. The returned map is a copy and may be modified freely. It
+// returns nil for an unknown system. Ranging over the map visits the units
+// in random order; ListUnits returns the units with their names in
+// ascending order.
+func UnitNames(system UnitSystem, long bool) map[Bytes]string {
+	switch {
+	case system == SI && long:
+		return maps.Clone(longDecimal)
+	case system == SI:
+		return maps.Clone(shortDecimal)
+	case system == IEC && long:
+		return maps.Clone(longBinary)
+	case system == IEC:
+		return maps.Clone(shortBinary)
+	case system == JEDEC:
+		return unitNameTable(jedecUnits, long)
+	default:
+		return nil
+	}
+}
+
+// Deprecated unit name maps. Modifying them has no effect on parsing or
+// formatting.
+var (
+	// Deprecated: Use UnitNames(SI, true).
+	LongDecimal = UnitNames(SI, true)
+	// Deprecated: Use UnitNames(SI, false).
+	ShortDecimal = UnitNames(SI, false)
+	// Deprecated: Use UnitNames(IEC, true).
+	LongBinary = UnitNames(IEC, true)
+	// Deprecated: Use UnitNames(IEC, false).
+	ShortBinary = UnitNames(IEC, false)
+)
+
+// NormalizeUnit returns the canonical short name of the unit s, which may
+// be any spelling Parse accepts, such as "GB" for "gigabytes" or "gb", and
+// the unit's size, for linters that rewrite configuration in a house
+// style. It returns an error if s is not a unit Parse accepts.
+func NormalizeUnit(s string) (canonical string, unit Bytes, err error) {
+	unit, err = getMultiplierByUnitString(s)
+	if err != nil {
+		return "", Bytes{}, err
+	}
+	u, _ := UnitOf(unit)
+	return u.Short, unit, nil
+}
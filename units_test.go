@@ -0,0 +1,220 @@
+package bytesize
+
+import "testing"
+
+// TestUnitNames tests that UnitNames returns the names of each system
+func TestUnitNames(t *testing.T) {
+	tests := []struct {
+		system UnitSystem
+		long   bool
+		unit   Bytes
+		want   string
+	}{
+		{SI, false, KB, "KB"},
+		{SI, true, QB, "Quettabyte"},
+		{IEC, false, MiB, "MiB"},
+		{IEC, true, GiB, "Gibibyte"},
+		{JEDEC, false, MiB, "MB"},
+		{JEDEC, true, KiB, "Kilobyte"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			names := UnitNames(tt.system, tt.long)
+			if want := len(ListUnits(tt.system)) - 1; len(names) != want {
+				t.Errorf("UnitNames(%v, %v) has %d names, want %d", tt.system, tt.long, len(names), want)
+			}
+			if got := names[tt.unit]; got != tt.want {
+				t.Errorf("UnitNames(%v, %v)[%v] = %q, want %q", tt.system, tt.long, Uint128(tt.unit), got, tt.want)
+			}
+		})
+	}
+
+	if names := UnitNames(UnitSystem(99), false); names != nil {
+		t.Errorf("UnitNames(99) = %v, want nil", names)
+	}
+}
+
+// TestUnitNamesImmutable tests that modifying the maps returned by
+// UnitNames or the deprecated maps does not affect formatting
+func TestUnitNamesImmutable(t *testing.T) {
+	UnitNames(SI, false)[MB] = "corrupted"
+	ShortDecimal[MB] = "corrupted"
+	defer func() { ShortDecimal[MB] = "MB" }()
+
+	if got := MB.String(); got != "1.00 MB" {
+		t.Errorf("MB.String() = %q after modifying unit name maps", got)
+	}
+	if got := UnitNames(SI, false)[MB]; got != "MB" {
+		t.Errorf("UnitNames(SI, false)[MB] = %q after modifying a copy", got)
+	}
+}
+
+// TestUnitSystemString tests the names of unit systems
+func TestUnitSystemString(t *testing.T) {
+	for system, want := range map[UnitSystem]string{SI: "SI", IEC: "IEC", JEDEC: "JEDEC", UnitSystem(7): "UnitSystem(7)"} {
+		if got := system.String(); got != want {
+			t.Errorf("UnitSystem(%d).String() = %q, want %q", int(system), got, want)
+		}
+	}
+}
+
+// TestListUnits tests the units listed for each system
+func TestListUnits(t *testing.T) {
+	tests := []struct {
+		system UnitSystem
+		count  int
+		index  int
+		want   Unit
+	}{
+		{SI, 11, 0, Unit{B, "B", "Byte", "B", SI}},
+		{SI, 11, 1, Unit{KB, "KB", "Kilobyte", "kB", SI}},
+		{SI, 11, 10, Unit{QB, "QB", "Quettabyte", "QB", SI}},
+		{IEC, 11, 3, Unit{GiB, "GiB", "Gibibyte", "GiB", IEC}},
+		{JEDEC, 4, 0, Unit{B, "B", "Byte", "B", JEDEC}},
+		{JEDEC, 4, 1, Unit{KiB, "KB", "Kilobyte", "KB", JEDEC}},
+		{JEDEC, 4, 3, Unit{GiB, "GB", "Gigabyte", "GB", JEDEC}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system.String()+" "+tt.want.Long, func(t *testing.T) {
+			units := ListUnits(tt.system)
+			if len(units) != tt.count {
+				t.Fatalf("ListUnits(%v) has %d units, want %d", tt.system, len(units), tt.count)
+			}
+			if units[tt.index] != tt.want {
+				t.Errorf("ListUnits(%v)[%d] = %+v, want %+v", tt.system, tt.index, units[tt.index], tt.want)
+			}
+			for i := 1; i < len(units); i++ {
+				if Uint128(units[i-1].Factor).Cmp(Uint128(units[i].Factor)) >= 0 {
+					t.Errorf("ListUnits(%v) is not in ascending order at %d", tt.system, i)
+				}
+			}
+		})
+	}
+
+	if units := ListUnits(UnitSystem(99)); units != nil {
+		t.Errorf("ListUnits(99) = %v, want nil", units)
+	}
+}
+
+// TestListUnitsImmutable tests that modifying the result of ListUnits does
+// not affect later calls
+func TestListUnitsImmutable(t *testing.T) {
+	ListUnits(JEDEC)[1].Short = "corrupted"
+	if got := ListUnits(JEDEC)[1].Short; got != "KB" {
+		t.Errorf("ListUnits(JEDEC)[1].Short = %q after modifying a previous result", got)
+	}
+}
+
+// TestUnitOf tests converting unit variables to Units
+func TestUnitOf(t *testing.T) {
+	tests := []struct {
+		b      Bytes
+		want   Unit
+		wantOK bool
+	}{
+		{B, Unit{B, "B", "Byte", "B", SI}, true},
+		{KB, Unit{KB, "KB", "Kilobyte", "kB", SI}, true},
+		{KiB, Unit{KiB, "KiB", "Kibibyte", "KiB", IEC}, true},
+		{QiB, Unit{QiB, "QiB", "Quettibyte", "QiB", IEC}, true},
+		{Bytes{1000, 1}, Unit{}, false},
+		{None, Unit{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := UnitOf(tt.b)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("UnitOf(%v) = %+v, %v, want %+v, %v", Uint128(tt.b), got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestFormatWithUnit tests formatting in a Unit, including units that share
+// a factor with another unit
+func TestFormatWithUnit(t *testing.T) {
+	jedecKB := ListUnits(JEDEC)[1]
+	tests := []struct {
+		name string
+		b    Bytes
+		opts []FormatOption
+		want string
+	}{
+		{"JEDEC KB", Bytes{1536, 0}, []FormatOption{WithUnit(jedecKB)}, "1.50 KB"},
+		{"JEDEC Kilobytes", Bytes{1536, 0}, []FormatOption{WithUnit(jedecKB), WithLongUnits(true)}, "1.50 Kilobytes"},
+		{"IEC KiB", Bytes{1536, 0}, []FormatOption{WithForcedUnit(KiB)}, "1.50 KiB"},
+		{"custom", Bytes{4096, 0}, []FormatOption{WithUnit(Unit{Factor: Bytes{4096, 0}, Short: "pg", Long: "Page"}), WithLongUnits(true)}, "1.00 Page"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.Format(tt.opts...)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWithUnitErrors tests that WithUnit rejects incomplete units
+func TestWithUnitErrors(t *testing.T) {
+	units := []Unit{
+		{},
+		{Factor: KB, Short: "KB"},
+		{Factor: None, Short: "x", Long: "X"},
+	}
+	for _, u := range units {
+		if _, err := KB.Format(WithUnit(u)); err == nil {
+			t.Errorf("Format(WithUnit(%+v)) returned no error", u)
+		}
+	}
+}
+
+// TestDefaultForcedUnitType tests that DefaultForcedUnitType still forces
+// the unit
+func TestDefaultForcedUnitType(t *testing.T) {
+	defer func(old *Bytes) { DefaultForcedUnitType = old }(DefaultForcedUnitType)
+
+	DefaultForcedUnitType = &MiB
+	if got := GiB.String(); got != "1024.00 MiB" {
+		t.Errorf("GiB.String() = %q with DefaultForcedUnitType MiB", got)
+	}
+}
+
+// TestNormalizeUnit tests mapping unit spellings to their short names
+func TestNormalizeUnit(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantCanonical string
+		wantUnit      Bytes
+		wantErr       bool
+	}{
+		{"gigabytes", "GB", GB, false},
+		{"Gigabyte", "GB", GB, false},
+		{"gb", "GB", GB, false},
+		{" GiB ", "GiB", GiB, false},
+		{"gibibytes", "GiB", GiB, false},
+		{"kb", "KB", KB, false},
+		{"bytes", "B", B, false},
+		{"b", "B", B, false},
+		{"QiB", "QiB", QiB, false},
+		{"k", "", None, true},
+		{"gigglebytes", "", None, true},
+		{"", "", None, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			canonical, unit, err := NormalizeUnit(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeUnit(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if canonical != tt.wantCanonical || unit != tt.wantUnit {
+				t.Errorf("NormalizeUnit(%q) = (%q, %v), want (%q, %v)", tt.input, canonical, Uint128(unit), tt.wantCanonical, Uint128(tt.wantUnit))
+			}
+		})
+	}
+}
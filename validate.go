@@ -0,0 +1,88 @@
+package bytesize
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Constraint checks a Bytes value, returning an error describing why the
+// value is not acceptable, or nil if it is.
+type Constraint func(b Bytes) error
+
+// Validate checks b against every constraint and returns all violations
+// joined into a single error, or nil if b satisfies all of them. It is
+// meant for config validation layers, where reporting every problem at once
+// is friendlier than stopping at the first.
+func Validate(b Bytes, constraints ...Constraint) error {
+	var errs []error
+	for _, constraint := range constraints {
+		if err := constraint(b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MinSize returns a Constraint requiring a value of at least min. (The
+// name Max is taken by the largest Uint128, hence the Size suffix on both
+// bounds.)
+func MinSize(min Bytes) Constraint {
+	return func(b Bytes) error {
+		if Uint128(b).CmpBytes(min) < 0 {
+			return fmt.Errorf("%s is less than the minimum of %s", describe(b), describe(min))
+		}
+		return nil
+	}
+}
+
+// MaxSize returns a Constraint requiring a value of at most max.
+func MaxSize(max Bytes) Constraint {
+	return func(b Bytes) error {
+		if Uint128(b).CmpBytes(max) > 0 {
+			return fmt.Errorf("%s is greater than the maximum of %s", describe(b), describe(max))
+		}
+		return nil
+	}
+}
+
+// MultipleOf returns a Constraint requiring a value that is a whole multiple
+// of unit, such as a page or block size. Zero is a multiple of every unit.
+func MultipleOf(unit Bytes) Constraint {
+	return func(b Bytes) error {
+		if Uint128(unit).IsZero() {
+			return fmt.Errorf("invalid constraint: multiple of zero")
+		}
+		if !Uint128(b).ModBytes(unit).IsZero() {
+			return fmt.Errorf("%s is not a multiple of %s", describe(b), describe(unit))
+		}
+		return nil
+	}
+}
+
+// NonZero returns a Constraint requiring a value greater than zero.
+func NonZero() Constraint {
+	return func(b Bytes) error {
+		if Uint128(b).IsZero() {
+			return fmt.Errorf("value must not be zero")
+		}
+		return nil
+	}
+}
+
+// describe formats b for an error message. Values that are whole multiples
+// of a KiB but not of a KB are shown in binary units, so that a limit
+// configured as "1 MiB" is reported as such, and the exact count is given
+// whenever the humanized form is not exact.
+func describe(b Bytes) string {
+	decimal := Uint128(b).ModBytes(KB).IsZero() || !Uint128(b).ModBytes(KiB).IsZero()
+	str, err := b.Format(WithDecimalUnits(decimal))
+	if err != nil {
+		return fmt.Sprintf("%s B", Uint128(b))
+	}
+
+	exact, err := Parse(str)
+	if err != nil || exact != b {
+		return fmt.Sprintf("%s (%s B)", str, Uint128(b))
+	}
+	return str
+}
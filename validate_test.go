@@ -0,0 +1,77 @@
+package bytesize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestValidate tests Validate with each of the constraints
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       Bytes
+		constraints []Constraint
+		wantErrs    []string
+	}{
+		{"no constraints", KB, nil, nil},
+		{"within bounds", GiB, []Constraint{MinSize(MiB), MaxSize(Bytes(Uint128(GiB).Mul64(10)))}, nil},
+		{"below minimum", KiB, []Constraint{MinSize(MiB)}, []string{"1.00 KiB is less than the minimum of 1.00 MiB"}},
+		{"above maximum", TB, []Constraint{MaxSize(Bytes(Uint128(GiB).Mul64(10)))}, []string{"1.00 TB is greater than the maximum of 10.00 GiB"}},
+		{"multiple of", Bytes(Uint128(KiB).Mul64(8)), []Constraint{MultipleOf(Bytes(Uint128(KiB).Mul64(4)))}, nil},
+		{"not a multiple of", Bytes{5000, 0}, []Constraint{MultipleOf(Bytes(Uint128(KiB).Mul64(4)))}, []string{"5.00 KB is not a multiple of 4.00 KiB"}},
+		{"multiple of zero", KB, []Constraint{MultipleOf(None)}, []string{"multiple of zero"}},
+		{"non-zero", B, []Constraint{NonZero()}, nil},
+		{"zero", None, []Constraint{NonZero()}, []string{"must not be zero"}},
+		{"inexact value", Bytes{1234567, 0}, []Constraint{MaxSize(MB)}, []string{"1.23 MB (1234567 B)"}},
+		{
+			"aggregated",
+			Bytes{100, 0},
+			[]Constraint{MinSize(MiB), MultipleOf(KiB), NonZero()},
+			[]string{"100.00 B is less than the minimum of 1.00 MiB", "100.00 B is not a multiple of 1.00 KiB"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.input, tt.constraints...)
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %q", tt.wantErrs)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %q, expected to contain %q", err, want)
+				}
+			}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				if got := len(joined.Unwrap()); got != len(tt.wantErrs) {
+					t.Errorf("Validate() returned %d errors, want %d", got, len(tt.wantErrs))
+				}
+			}
+		})
+	}
+}
+
+// TestValidateCustomConstraint tests that callers can supply their own
+// constraints
+func TestValidateCustomConstraint(t *testing.T) {
+	errOdd := errors.New("odd")
+	even := func(b Bytes) error {
+		if b.Lo%2 != 0 {
+			return errOdd
+		}
+		return nil
+	}
+	if err := Validate(Bytes{3, 0}, even); !errors.Is(err, errOdd) {
+		t.Errorf("Validate() error = %v, want %v", err, errOdd)
+	}
+	if err := Validate(Bytes{4, 0}, even); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,86 @@
+package bytesize
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WatermarkState is the state of a Watermarks tracker.
+type WatermarkState int
+
+const (
+	// WatermarkNormal means usage has not reached the high watermark, or has
+	// since dropped below the low watermark.
+	WatermarkNormal WatermarkState = iota
+	// WatermarkHigh means usage reached the high watermark and has not yet
+	// dropped below the low watermark.
+	WatermarkHigh
+)
+
+// String returns the name of the watermark state.
+func (s WatermarkState) String() string {
+	switch s {
+	case WatermarkNormal:
+		return "normal"
+	case WatermarkHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("WatermarkState(%d)", int(s))
+	}
+}
+
+// Watermarks tracks usage against a pair of thresholds with hysteresis: the
+// state becomes WatermarkHigh once usage reaches High, and only returns to
+// WatermarkNormal once usage drops below Low. This keeps cache eviction or
+// disk-pressure handling from flapping while usage hovers around a single
+// threshold. Low should not be greater than High.
+//
+// The zero value is ready to use once Low and High are set. A Watermarks
+// must not be copied after first use, and is safe for concurrent use.
+type Watermarks struct {
+	Low, High Bytes
+
+	mu        sync.Mutex
+	state     WatermarkState
+	callbacks []func(from, to WatermarkState, current Bytes)
+}
+
+// OnChange registers fn to be called whenever a call to State changes the
+// state. Callbacks are called in registration order, on the goroutine that
+// called State, after the state has been updated.
+func (w *Watermarks) OnChange(fn func(from, to WatermarkState, current Bytes)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, fn)
+}
+
+// State updates the tracker with the current usage and returns the
+// resulting state.
+func (w *Watermarks) State(current Bytes) WatermarkState {
+	w.mu.Lock()
+	from := w.state
+	switch {
+	case from == WatermarkNormal && Uint128(current).CmpBytes(w.High) >= 0:
+		w.state = WatermarkHigh
+	case from == WatermarkHigh && Uint128(current).CmpBytes(w.Low) < 0:
+		w.state = WatermarkNormal
+	}
+	to := w.state
+	callbacks := w.callbacks
+	w.mu.Unlock()
+
+	if from != to {
+		for _, fn := range callbacks {
+			fn(from, to, current)
+		}
+	}
+	return to
+}
+
+// String returns the thresholds and current state, formatted with the
+// default format options.
+func (w *Watermarks) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return fmt.Sprintf("low %s, high %s, %s", w.Low, w.High, w.state)
+}
@@ -0,0 +1,68 @@
+package bytesize
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestWatermarks tests the hysteresis between the low and high watermarks
+func TestWatermarks(t *testing.T) {
+	w := &Watermarks{Low: Bytes{80, 0}, High: Bytes{90, 0}}
+
+	var transitions []string
+	w.OnChange(func(from, to WatermarkState, current Bytes) {
+		transitions = append(transitions, fmt.Sprintf("%s->%s@%d", from, to, current.Lo))
+	})
+
+	steps := []struct {
+		current uint64
+		want    WatermarkState
+	}{
+		{50, WatermarkNormal},
+		{85, WatermarkNormal},
+		{90, WatermarkHigh},
+		{95, WatermarkHigh},
+		{85, WatermarkHigh},
+		{80, WatermarkHigh},
+		{79, WatermarkNormal},
+		{89, WatermarkNormal},
+		{100, WatermarkHigh},
+	}
+
+	for i, step := range steps {
+		if got := w.State(Bytes{step.current, 0}); got != step.want {
+			t.Errorf("step %d: State(%d) = %s, want %s", i, step.current, got, step.want)
+		}
+	}
+
+	want := []string{"normal->high@90", "high->normal@79", "normal->high@100"}
+	if fmt.Sprint(transitions) != fmt.Sprint(want) {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+}
+
+// TestWatermarksEqualThresholds tests that equal thresholds behave like a
+// single threshold
+func TestWatermarksEqualThresholds(t *testing.T) {
+	w := &Watermarks{Low: KB, High: KB}
+	if got := w.State(KB); got != WatermarkHigh {
+		t.Errorf("State(KB) = %s, want high", got)
+	}
+	if got := w.State(KB); got != WatermarkHigh {
+		t.Errorf("State(KB) again = %s, want high", got)
+	}
+	if got := w.State(Bytes{999, 0}); got != WatermarkNormal {
+		t.Errorf("State(999) = %s, want normal", got)
+	}
+}
+
+// TestWatermarksString tests formatting of the watermarks and their state
+func TestWatermarksString(t *testing.T) {
+	w := &Watermarks{Low: Bytes(Uint128(GB).Mul64(80)), High: Bytes(Uint128(GB).Mul64(90))}
+	if got, want := w.String(), "low 80.00 GB, high 90.00 GB, normal"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := WatermarkState(7).String(), "WatermarkState(7)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package bytesize
+
+import (
+	"io"
+	"sync"
+)
+
+// CountingWriter is an io.Writer that counts the bytes written through it
+// to an underlying writer. Count is safe to call concurrently with Write,
+// for example from a goroutine reporting progress.
+type CountingWriter struct {
+	w     io.Writer
+	mu    sync.Mutex
+	count Bytes
+}
+
+// NewCountingWriter returns a CountingWriter that writes to w.
+func NewCountingWriter(w io.Writer) *CountingWriter {
+	return &CountingWriter{w: w}
+}
+
+// Write writes p to the underlying writer and counts the bytes it accepted,
+// which are fewer than len(p) if it returns an error.
+func (c *CountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.mu.Lock()
+	c.count = Bytes(Uint128(c.count).Add64(uint64(n)))
+	c.mu.Unlock()
+	return n, err
+}
+
+// Count returns the number of bytes written so far.
+func (c *CountingWriter) Count() Bytes {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count
+}
+
+// ThresholdWriter is a CountingWriter that calls a function each time the
+// count crosses another multiple of a threshold, e.g. to log the progress
+// of a long copy every 100 MiB.
+type ThresholdWriter struct {
+	CountingWriter
+	every Bytes
+	next  Bytes // the next multiple of every to cross, zero after the last
+	fn    func(total Bytes)
+}
+
+// NewThresholdWriter returns a ThresholdWriter that writes to w and calls
+// fn with the total written whenever a write takes the total to or past
+// the next multiple of every. A write that crosses several multiples calls
+// fn once. fn is called synchronously from Write. It panics if every is
+// zero.
+func NewThresholdWriter(w io.Writer, every Bytes, fn func(total Bytes)) *ThresholdWriter {
+	if Uint128(every).IsZero() {
+		panic("bytesize: NewThresholdWriter with zero threshold")
+	}
+	return &ThresholdWriter{CountingWriter: CountingWriter{w: w}, every: every, next: every, fn: fn}
+}
+
+// Write writes p to the underlying writer, counts the bytes it accepted
+// and calls the threshold function if they crossed a threshold.
+func (t *ThresholdWriter) Write(p []byte) (int, error) {
+	n, err := t.CountingWriter.Write(p)
+	total := t.Count()
+	if !Uint128(t.next).IsZero() && Uint128(total).CmpBytes(t.next) >= 0 {
+		// Round the total down to a multiple of every, then step past it
+		_, rem := Uint128(total).QuoRemBytes(t.every)
+		next, overflow := Uint128(total).SubBytes(Bytes(rem)).AddBytesErr(t.every)
+		if overflow != nil {
+			next = Zero
+		}
+		t.next = Bytes(next)
+		t.fn(total)
+	}
+	return n, err
+}
@@ -0,0 +1,95 @@
+package bytesize
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestCountingWriter tests counting the bytes written through a writer
+func TestCountingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCountingWriter(&buf)
+	for _, s := range []string{"hello", ", ", "world"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatalf("Write(%q) error = %v", s, err)
+		}
+	}
+	if got, want := w.Count(), (Bytes{12, 0}); got != want {
+		t.Errorf("Count() = %v, want %v", got, want)
+	}
+	if got := buf.String(); got != "hello, world" {
+		t.Errorf("written = %q, want %q", got, "hello, world")
+	}
+}
+
+// shortWriter accepts at most limit bytes in total.
+type shortWriter struct {
+	limit int
+}
+
+func (s *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > s.limit {
+		n := s.limit
+		s.limit = 0
+		return n, errors.New("short write")
+	}
+	s.limit -= len(p)
+	return len(p), nil
+}
+
+// TestCountingWriterShortWrite tests that only accepted bytes are counted
+func TestCountingWriterShortWrite(t *testing.T) {
+	w := NewCountingWriter(&shortWriter{limit: 3})
+	n, err := w.Write([]byte("hello"))
+	if n != 3 || err == nil {
+		t.Fatalf("Write() = %d, %v, want 3 and an error", n, err)
+	}
+	if got, want := w.Count(), (Bytes{3, 0}); got != want {
+		t.Errorf("Count() = %v, want %v", got, want)
+	}
+}
+
+// TestThresholdWriter tests calling a function as thresholds are crossed
+func TestThresholdWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		every  uint64
+		writes []int
+		want   []uint64
+	}{
+		{"below", 10, []int{3, 6}, nil},
+		{"exact", 10, []int{5, 5, 10}, []uint64{10, 20}},
+		{"crossing", 10, []int{7, 7, 7, 7, 7}, []uint64{14, 21, 35}},
+		{"several at once", 10, []int{35, 3, 2}, []uint64{35, 40}},
+		{"every byte", 1, []int{1, 0, 2}, []uint64{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint64
+			w := NewThresholdWriter(&bytes.Buffer{}, Bytes{tt.every, 0}, func(total Bytes) {
+				got = append(got, total.Lo)
+			})
+			for _, n := range tt.writes {
+				if _, err := w.Write(make([]byte, n)); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("thresholds = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestThresholdWriterZero tests that a zero threshold panics
+func TestThresholdWriterZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewThresholdWriter with zero threshold did not panic")
+		}
+	}()
+	NewThresholdWriter(&bytes.Buffer{}, None, func(Bytes) {})
+}
@@ -0,0 +1,10 @@
+module github.com/beauhoyt/bytesize/zapbytesize
+
+go 1.24.13
+
+require (
+	github.com/beauhoyt/bytesize v0.0.0-20261016161142-f62705ad9806
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
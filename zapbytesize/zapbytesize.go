@@ -0,0 +1,33 @@
+// Package zapbytesize provides zap fields for bytesize.Bytes values that log
+// both the humanized and the exact size. It is a separate module so that
+// importing bytesize does not pull in zap.
+package zapbytesize
+
+import (
+	"github.com/beauhoyt/bytesize"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field returns a zap field that logs b as an object with a "human" key
+// holding the formatted size and a "bytes" key holding the exact count.
+func Field(key string, b bytesize.Bytes) zap.Field {
+	return zap.Object(key, Object(b))
+}
+
+// Object adapts a bytesize.Bytes value to zapcore.ObjectMarshaler.
+type Object bytesize.Bytes
+
+// MarshalLogObject implements zapcore.ObjectMarshaler. The exact count is
+// logged as a number when it fits in a uint64, and as a base-10 string
+// otherwise.
+func (o Object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	b := bytesize.Bytes(o)
+	enc.AddString("human", b.String())
+	if b.Hi == 0 {
+		enc.AddUint64("bytes", b.Lo)
+	} else {
+		enc.AddString("bytes", bytesize.Uint128(b).String())
+	}
+	return nil
+}
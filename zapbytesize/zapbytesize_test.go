@@ -0,0 +1,40 @@
+package zapbytesize
+
+import (
+	"testing"
+
+	"github.com/beauhoyt/bytesize"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestField tests the human and exact values logged for a size
+func TestField(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     bytesize.Bytes
+		wantHuman string
+		wantBytes any
+	}{
+		{"zero", bytesize.None, "0.00 B", uint64(0)},
+		{"megabytes", bytesize.Bytes{Lo: 1_234_567}, "1.23 MB", uint64(1_234_567)},
+		{"zebibyte", bytesize.ZiB, "1.18 ZB", "1180591620717411303424"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			Field("size", tt.input).AddTo(enc)
+
+			obj, ok := enc.Fields["size"].(map[string]any)
+			if !ok {
+				t.Fatalf("Field() logged %#v, want an object", enc.Fields["size"])
+			}
+			if obj["human"] != tt.wantHuman {
+				t.Errorf("human = %#v, want %#v", obj["human"], tt.wantHuman)
+			}
+			if obj["bytes"] != tt.wantBytes {
+				t.Errorf("bytes = %#v, want %#v", obj["bytes"], tt.wantBytes)
+			}
+		})
+	}
+}
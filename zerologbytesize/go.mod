@@ -0,0 +1,14 @@
+module github.com/beauhoyt/bytesize/zerologbytesize
+
+go 1.24.13
+
+require (
+	github.com/beauhoyt/bytesize v0.0.0-20261016161142-f62705ad9806
+	github.com/rs/zerolog v1.34.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+)
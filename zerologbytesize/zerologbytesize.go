@@ -0,0 +1,29 @@
+// Package zerologbytesize provides a zerolog object marshaler for
+// bytesize.Bytes values that logs both the humanized and the exact size. It
+// is a separate module so that importing bytesize does not pull in
+// zerolog.
+package zerologbytesize
+
+import (
+	"github.com/beauhoyt/bytesize"
+	"github.com/rs/zerolog"
+)
+
+// Object adapts a bytesize.Bytes value to zerolog.LogObjectMarshaler, e.g.
+//
+//	log.Info().Object("size", zerologbytesize.Object(b)).Msg("uploaded")
+type Object bytesize.Bytes
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler. It logs a
+// "human" key holding the formatted size and a "bytes" key holding the exact
+// count, as a number when it fits in a uint64 and as a base-10 string
+// otherwise.
+func (o Object) MarshalZerologObject(e *zerolog.Event) {
+	b := bytesize.Bytes(o)
+	e.Str("human", b.String())
+	if b.Hi == 0 {
+		e.Uint64("bytes", b.Lo)
+	} else {
+		e.Str("bytes", bytesize.Uint128(b).String())
+	}
+}
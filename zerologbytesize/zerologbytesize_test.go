@@ -0,0 +1,34 @@
+package zerologbytesize
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/beauhoyt/bytesize"
+	"github.com/rs/zerolog"
+)
+
+// TestMarshalZerologObject tests the human and exact values logged for a
+// size
+func TestMarshalZerologObject(t *testing.T) {
+	tests := []struct {
+		name  string
+		input bytesize.Bytes
+		want  string
+	}{
+		{"zero", bytesize.None, `{"size":{"human":"0.00 B","bytes":0}}` + "\n"},
+		{"megabytes", bytesize.Bytes{Lo: 1_234_567}, `{"size":{"human":"1.23 MB","bytes":1234567}}` + "\n"},
+		{"zebibyte", bytesize.ZiB, `{"size":{"human":"1.18 ZB","bytes":"1180591620717411303424"}}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+			logger.Log().Object("size", Object(tt.input)).Send()
+			if got := buf.String(); got != tt.want {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}